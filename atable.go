@@ -1,4 +1,4 @@
-// Copyright © 2016,2018,2025 Pennock Tech, LLC.
+// Copyright © 2016,2018,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -21,13 +21,23 @@ type ATable struct {
 	*ErrorContainer
 	propertyImpl
 	headerRow                 *Row
+	headerGroupRows           []*Row
+	title                     string
+	subtitle                  string
 	rows                      []*Row
+	footerRows                []*Row
+	totalsRow                 *Row
+	totalsLabel               any
 	nColumns                  int
 	columnNames               map[string]int // internal use, so the int places 0 as first column, not the default properties column
 	columns                   []Column       // has nColumns+1 entries
 	tableItselfCallbacks      callbackSet    // only useful for render-time
 	tableCellCallbacks        callbackSet
 	tableRowAdditionCallbacks callbackSet
+	schemaSet                 bool          // schema row callback registered
+	formulas                  *formulaGraph // nil until a formula is seen
+	computing                 bool          // computed column callbacks registered
+	layout                    int           // bumped whenever rows or columns are rearranged
 }
 
 type Column struct {
@@ -35,6 +45,11 @@ type Column struct {
 	ofTable               *ATable
 	cellCallbacks         callbackSet
 	columnItselfCallbacks callbackSet
+	aggregator            Aggregator
+	aggregating           bool // totals callback registered
+	spec                  *ColumnSpec
+	checking              bool // schema callback registered
+	compute               func(*Row) any
 	propertyImpl
 }
 
@@ -97,11 +112,26 @@ func (t *ATable) ColumnNamed(name string) (*Column, error) {
 // AddRow adds a *Row to the *ATable, returning the table to allow for chaining.
 // Any errors accumulate in the table.
 // Any existing errors in the row become table errors.
+// If cells in rows above span down into this row, then the row's cells are
+// moved along to make room for the covered positions.
+// Any cell holding a Fielder or AnonFielder is replaced by a cell for each
+// field.
 func (t *ATable) AddRow(row *Row) Table {
+	row.expandFielders()
+	t.flowAroundRowSpans(row, len(t.rows)+1)
 	t.rows = append(t.rows, row)
 	row.inTable = t
 	row.rowNum = len(t.rows)
+	t.layout++
+	t.markRowSpans(len(t.rows) - 1)
 	t.resizeColumnsAtLeast(len(row.cells))
+	t.adoptRow(row)
+	return t
+}
+
+// adoptRow takes over the errors of a row which has just been placed in the
+// body of the table, and invokes the addition-time callbacks.
+func (t *ATable) adoptRow(row *Row) {
 	// swallow existing errors
 	es := row.Errors()
 	if es != nil {
@@ -121,9 +151,11 @@ func (t *ATable) AddRow(row *Row) Table {
 			invokePropertyCallbacks(col.cellCallbacks, CB_AT_ADD, ptr, row.ErrorContainer)
 		}
 		invokePropertyCallbacks(t.tableCellCallbacks, CB_AT_ADD, ptr, row.ErrorContainer)
+		if f, ok := ptr.raw.(*Formula); ok {
+			ptr.raw = f.place()
+			t.enableFormulas()
+		}
 	}
-
-	return t
 }
 
 // AddSeparator adds a rule to the table.
@@ -132,6 +164,7 @@ func (t *ATable) AddSeparator() Table {
 	t.rows = append(t.rows, sep)
 	sep.inTable = t
 	sep.rowNum = len(t.rows)
+	t.layout++
 	return t
 }
 
@@ -157,19 +190,29 @@ func (t *ATable) Headers() []Cell {
 
 // AddHeaders creates a header-row from the passed items and sets it
 // as the table's header row.  The table is returned.
+// Any item which is a Cell spanning columns is used as that cell, so a
+// header can span columns; any other Cell is held by a new cell.  Any item
+// which is a Fielder, or AnonFielder, supplies a header for each of its
+// fields, so a type can name its own columns.
 func (t *ATable) AddHeaders(items ...any) Table {
-	t.resizeColumnsAtLeast(len(items))
 	hr := NewRowWithCapacity(len(items))
-	columnNames := make(map[string]int, len(items))
 	hr.ErrorContainer = t.ErrorContainer
 	for i := range items {
-		cell := NewCell(items[i])
-		hr.Add(cell)
-		columnNames[cell.String()] = i
+		hr.Add(cellForItem(items[i]))
 	}
+	hr.expandFielders()
+	t.resizeColumnsAtLeast(len(hr.cells))
 	t.headerRow = hr
-	t.columnNames = columnNames
+	markColumnSpans(hr)
+	t.rebuildColumnNames()
 
+	t.invokeOuterRowAddCallbacks(hr)
+	return t
+}
+
+// invokeOuterRowAddCallbacks handles the addition-time callbacks for a row
+// outside the body: a header row, a header group row or a footer row.
+func (t *ATable) invokeOuterRowAddCallbacks(hr *Row) {
 	invokePropertyCallbacks(t.tableRowAdditionCallbacks, CB_AT_ADD, hr, t.ErrorContainer)
 	for i := range hr.cells {
 		ptr := &hr.cells[i]
@@ -178,7 +221,6 @@ func (t *ATable) AddHeaders(items ...any) Table {
 		}
 		invokePropertyCallbacks(t.tableCellCallbacks, CB_AT_ADD, ptr, t.ErrorContainer)
 	}
-	return t
 }
 
 // AllRows returns an iterable of rows.
@@ -194,6 +236,8 @@ func (t *ATable) AllRows() []*Row {
 
 // CellAt returns a pointer to the cell found at the given row and column
 // coordinates, where the top-left item is 1,1.
+// If the location lies within the region of a cell which spans multiple
+// columns or rows, then the spanning cell is returned.
 func (t *ATable) CellAt(loc CellLocation) (*Cell, error) {
 	if loc.Row < 1 || loc.Column < 1 || loc.Row > len(t.rows) {
 		return nil, NoSuchCellError{Location: loc}
@@ -202,5 +246,16 @@ func (t *ATable) CellAt(loc CellLocation) (*Cell, error) {
 	if r.cells == nil || loc.Column > len(r.cells) {
 		return nil, NoSuchCellError{Location: loc}
 	}
-	return &r.cells[loc.Column-1], nil
+	return r.cells[loc.Column-1].SpanAnchor(), nil
+}
+
+// SetCellAt replaces the item in the cell at the given location, per
+// Cell.SetItem, invoking the update-time callbacks.
+func (t *ATable) SetCellAt(loc CellLocation, item any) error {
+	c, err := t.CellAt(loc)
+	if err != nil {
+		return err
+	}
+	c.SetItem(item)
+	return nil
 }
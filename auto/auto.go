@@ -1,4 +1,4 @@
-// Copyright © 2016,2018 Pennock Tech, LLC.
+// Copyright © 2016,2018,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -40,9 +40,16 @@ type RenderTable interface {
 //
 // The style sections after the first are interpreted dependent upon the first
 // section and not yet locked down by API.
+//
+// One section is understood whatever the first section: "cols=" followed by
+// a comma-separated list of column names renders a projection of the table
+// showing just those columns, in that order, as Wrap(t, "csv.cols=name,size").
+// If a column name is unknown then the error is added to the table, and all
+// of the columns are shown.
 func Wrap(t tabular.Table, style string) RenderTable {
 	var rt RenderTable
 	sections := strings.Split(style, ".")
+	t, sections = projectFromSections(t, sections)
 	switch strings.ToLower(sections[0]) {
 	case "csv":
 		rt = csv.Wrap(t)
@@ -52,7 +59,16 @@ func Wrap(t tabular.Table, style string) RenderTable {
 	case "markdown":
 		rt = markdown.Wrap(t)
 	case "json":
-		rt = json.Wrap(t)
+		jt := json.Wrap(t)
+		for _, section := range sections[1:] {
+			switch strings.ToLower(section) {
+			case "envelope":
+				jt.SetEnvelope(true)
+			case "!envelope":
+				jt.SetEnvelope(false)
+			}
+		}
+		rt = jt
 	case "texttable":
 		tt := texttable.Wrap(t)
 		setColorsOrDecorationsFromSections(tt, sections[1:])
@@ -65,6 +81,28 @@ func Wrap(t tabular.Table, style string) RenderTable {
 	return rt
 }
 
+// projectFromSections handles any "cols=" sections of the style, returning
+// the table to render and the remaining sections.
+func projectFromSections(t tabular.Table, sections []string) (tabular.Table, []string) {
+	remaining := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if !strings.HasPrefix(strings.ToLower(section), "cols=") {
+			remaining = append(remaining, section)
+			continue
+		}
+		projected, err := t.Project(strings.Split(section[5:], ",")...)
+		if err != nil {
+			t.AddError(err)
+			continue
+		}
+		t = projected
+	}
+	if len(remaining) == 0 {
+		remaining = append(remaining, "")
+	}
+	return t, remaining
+}
+
 var reColorHex *regexp.Regexp
 
 func init() {
@@ -128,6 +166,10 @@ func setColorsOrDecorationsFromSections(tt *texttable.TextTable, sections []stri
 			tt.SetColorToEOL(true)
 		} else if section == "!fullwidth" || section == "!toeol" {
 			tt.SetColorToEOL(false)
+		} else if section == "overlay" {
+			tt.SetTitleOverlay(true)
+		} else if section == "!overlay" {
+			tt.SetTitleOverlay(false)
 		} else if !doneDecoration {
 			tt.SetDecorationNamed(section)
 			doneDecoration = true
@@ -166,7 +208,8 @@ func setColorsOrDecorationsFromSections(tt *texttable.TextTable, sections []stri
 	}
 }
 
-// New creates a new tabular.Table and Wrap()s it.
+// New creates a new tabular.Table and Wrap()s it.  The new table has no
+// columns, so the style should not select any with a "cols=" section.
 func New(style string) RenderTable {
 	return Wrap(tabular.New(), style)
 }
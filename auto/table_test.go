@@ -194,3 +194,42 @@ func TestColor(t *testing.T) {
 	have = buf.String()
 	T.Equal(have, expectedSolid, "got solid colored table text")
 }
+
+func TestWrapColumns(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	populate(T, tb)
+
+	rendered, err := auto.Wrap(tb, "csv.cols=x,foo").Render()
+	T.ExpectSuccess(err, "render projection as csv")
+	T.Equal(rendered, `"x","foo"
+"fred","42"
+"r","snerty"
+""," "
+`, "csv of selected columns")
+
+	rendered, err = auto.Wrap(tb, "cols=loquacious.ascii-simple").Render()
+	T.ExpectSuccess(err, "render projection as texttable")
+	T.Equal(rendered, strings.TrimLeft(`
++------------+
+| loquacious |
++------------+
+| .          |
+| word       |
++------------+
+| true       |
++------------+
+`, "\n"), "texttable of selected column")
+	T.Equal(tb.Errors(), nil, "no errors from known columns")
+
+	rendered, err = auto.Wrap(tb, "csv.cols=foo,missing").Render()
+	T.ExpectSuccess(err, "render despite unknown column")
+	T.Equal(rendered, `"foo","loquacious","x"
+"42",".","fred"
+"snerty","word","r"
+" ","true",""
+`, "unknown column shows all columns")
+	T.Equal(tb.Errors(), []error{tabular.ErrorNoSuchColumn("missing")}, "unknown column recorded")
+}
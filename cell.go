@@ -1,4 +1,4 @@
-// Copyright © 2016,2018,2025 Pennock Tech, LLC.
+// Copyright © 2016,2018,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -6,7 +6,6 @@ package tabular // import "go.pennock.tech/tabular"
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 
 	"go.pennock.tech/tabular/length"
@@ -31,9 +30,13 @@ type Cell struct {
 	// When within a row, holds row
 	inRow *Row
 
-	// Do we need to hold something saying that we're in a row, like a row
-	// holds a table, so that if a .SetItem() is done, we can re-trigger row
-	// (and column/table) callbacks?
+	// If set, the fmt.Sprintf format with which the item is shown
+	format string
+
+	// For a cell spanning multiple columns and/or rows, its extent; for a
+	// placeholder covered by such a cell, where that cell is.
+	span     cellSpan
+	spanFrom spanOrigin
 
 	// NB: at present, this just means "calculate the contained raw, then use the values"
 	// but that doesn't need to be the case;
@@ -51,6 +54,20 @@ func NewCell(object any) Cell {
 	return c
 }
 
+// cellForItem returns the Cell to use for an item passed to one of the
+// convenience methods taking items: a Cell which spans columns or rows is
+// used as-is, so that spanning cells can be mixed in with plain items, while
+// any other item, including a Cell which does not span, is held by a new cell
+// as it always was.
+func cellForItem(item any) Cell {
+	if c, ok := item.(Cell); ok {
+		if columns, rows := c.Span(); columns > 1 || rows > 1 {
+			return c
+		}
+	}
+	return NewCell(item)
+}
+
 // Update changes metadata to reflect the current state of the object stored in
 // a cell.
 func (c *Cell) Update() {
@@ -90,6 +107,9 @@ func (c *Cell) Update() {
 	default:
 		c.str = fmt.Sprintf("%v", o)
 	}
+	if c.format != "" {
+		c.str = fmt.Sprintf(c.format, c.raw)
+	}
 
 	overrideOnly := false
 	if c.str == "" {
@@ -99,7 +119,7 @@ func (c *Cell) Update() {
 		overrideOnly = true
 	}
 
-	if h, ok := c.raw.(Heighter); ok {
+	if h, ok := c.raw.(Heighter); ok && c.format == "" {
 		c.height = h.Height()
 	} else if !overrideOnly {
 		c.height = 1 + strings.Count(c.str, "\n")
@@ -107,7 +127,7 @@ func (c *Cell) Update() {
 			c.height -= 1
 		}
 	}
-	if w, ok := c.raw.(TerminalCellWidther); ok {
+	if w, ok := c.raw.(TerminalCellWidther); ok && c.format == "" {
 		c.width = w.TerminalCellWidth()
 	} else if !overrideOnly {
 		c.width = length.LongestLineCells(c.str)
@@ -121,11 +141,56 @@ func (c *Cell) updateCache() {
 	c.mustCalc = false
 }
 
+// SetItem replaces the object stored inside a cell, updating the metadata,
+// then invokes the update-time callbacks, per CB_AT_UPDATE, so that
+// properties derived from the cell's content can be recomputed.  Upon a
+// placeholder covered by a cell spanning columns or rows, the spanning cell
+// is changed instead.
+func (c *Cell) SetItem(item any) {
+	c = c.SpanAnchor()
+	c.raw = item
+	c.Update()
+
+	row := c.inRow
+	var ec *ErrorContainer
+	if row != nil {
+		ec = row.ErrorContainer
+	}
+	invokePropertyCallbacks(c.callbacks, CB_AT_UPDATE, c, ec)
+	if row == nil {
+		return
+	}
+	invokePropertyCallbacks(row.rowCellCallbacks, CB_AT_UPDATE, c, ec)
+	col := c.columnOfTable()
+	if col != nil {
+		invokePropertyCallbacks(col.cellCallbacks, CB_AT_UPDATE, c, ec)
+	}
+	t := row.inTable
+	if t == nil {
+		invokePropertyCallbacks(row.rowItselfCallbacks, CB_AT_UPDATE, row, ec)
+		return
+	}
+	t.formulaChanged(c)
+	invokePropertyCallbacks(t.tableCellCallbacks, CB_AT_UPDATE, c, ec)
+	invokePropertyCallbacks(row.rowItselfCallbacks, CB_AT_UPDATE, row, ec)
+	invokePropertyCallbacks(t.tableRowAdditionCallbacks, CB_AT_UPDATE, row, ec)
+	if col != nil {
+		invokePropertyCallbacks(col.columnItselfCallbacks, CB_AT_UPDATE, col, ec)
+	}
+	invokePropertyCallbacks(t.tableItselfCallbacks, CB_AT_UPDATE, t, ec)
+}
+
 // Item returns the object stored inside a cell.
 func (c Cell) Item() any {
 	return c.raw
 }
 
+// Format returns the fmt.Sprintf format with which the cell shows its item,
+// as set by the format option of AddStructs or by a ColumnSpec, or "".
+func (c Cell) Format() string {
+	return c.format
+}
+
 // String returns some string representation of the content of a cell.
 func (c Cell) String() string {
 	if c.mustCalc {
@@ -205,98 +270,9 @@ func (c *Cell) Row() *Row {
 
 // LessThan returns true if the value of this cell is less than the value of
 // the other cell.  The determination of "less" is euphemistically heuristic.
+// A cell holding nil is less than any cell holding something, where once
+// LessThan panicked.
 func (c *Cell) LessThan(d *Cell) bool {
-	var (
-		g, h, t         *Cell
-		u               Cell
-		ok              bool
-		cv, dv          reflect.Value
-		tt, sortIntType reflect.Type
-		as              string
-		af              float64
-		aOkay           bool
-	)
-
-	g, ok = c, true
-	for ok {
-		if t, ok = g.raw.(*Cell); ok {
-			g = t
-		} else if u, ok = g.raw.(Cell); ok {
-			g = &u
-		}
-	}
-	cv = reflect.ValueOf(g.raw)
-
-	h, ok = d, true
-	for ok {
-		if t, ok = h.raw.(*Cell); ok {
-			h = t
-		} else if u, ok = h.raw.(Cell); ok {
-			h = &u
-		}
-	}
-	dv = reflect.ValueOf(h.raw)
-
-	// Do not try to convert to uint, because positive floats convert and lose precision.
-	// Similarly for int.
-	// Leave _conversions_ for the float.  But "can" is the underlying type.
-	// We want to use SortInter as our _first_ choice, including when defined on types for which the underlying type is an int
-
-	sortIntType = reflect.TypeOf((*SortInter)(nil)).Elem()
-
-	if cv.Type().Implements(sortIntType) {
-		if dv.Type().Implements(sortIntType) {
-			return cv.Interface().(SortInter).SortInt64() < dv.Interface().(SortInter).SortInt64()
-		} else if dv.CanInt() {
-			return cv.Interface().(SortInter).SortInt64() < dv.Int()
-		}
-	} else if dv.Type().Implements(sortIntType) {
-		if cv.CanInt() {
-			return cv.Int() < dv.Interface().(SortInter).SortInt64()
-		}
-	}
-
-	if cv.CanFloat() && dv.CanFloat() {
-		return cv.Float() < dv.Float()
-	}
-	if cv.CanUint() && dv.CanUint() {
-		return cv.Uint() < dv.Uint()
-	}
-	if cv.CanInt() {
-		if dv.CanFloat() {
-			return float64(cv.Int()) < dv.Float()
-		} else if dv.CanInt() {
-			return cv.Int() < dv.Int()
-		}
-	} else if cv.CanFloat() && dv.CanInt() {
-		return cv.Float() < float64(dv.Int())
-	}
-
-	tt = reflect.TypeOf(af)
-	if cv.CanConvert(tt) && dv.CanConvert(tt) {
-		return cv.Convert(tt).Float() < dv.Convert(tt).Float()
-	}
-
-	aOkay = false
-	if x, ok := g.raw.(string); ok {
-		as = x
-		aOkay = true
-	} else if x, ok := g.raw.(Stringer); ok {
-		as = x.String()
-		aOkay = true
-	} else if x, ok := g.raw.(GoStringer); ok {
-		as = x.GoString()
-		aOkay = true
-	}
-	if aOkay {
-		if x, ok := h.raw.(string); ok {
-			return as < x
-		} else if x, ok := h.raw.(Stringer); ok {
-			return as < x.String()
-		} else if x, ok := h.raw.(GoStringer); ok {
-			return as < x.GoString()
-		}
-	}
-
-	return false
+	a, b := sortValueOf(c, false), sortValueOf(d, false)
+	return a.less(&b)
 }
@@ -0,0 +1,209 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+// Clone returns an independent copy of the table: its rows, cells and
+// columns, the properties of each and of the table itself, its title, header
+// groups, footer, aggregators and errors.  Changing the properties or cells of
+// either afterwards does not affect the other.  The items held in the cells,
+// and the values of properties, are not themselves copied.
+//
+// If callbacks is true then the registered callbacks are carried across too;
+// the callbacks themselves are shared, so any holding state of their own, such
+// as those a renderer registers when wrapping a table, act for both tables.
+// Otherwise the copy has no callbacks, beyond those computing the totals row,
+// applying any schema, evaluating formulas and filling computed columns.  Each
+// formula is copied, so that the copy's formulas read the copy's cells.
+func (t *ATable) Clone(callbacks bool) Table {
+	return t.clone(callbacks)
+}
+
+// Clone returns a view of a clone of the table beneath, showing the same rows.
+func (fv *FilterView) Clone(callbacks bool) Table {
+	return &FilterView{Table: fv.Table.Clone(callbacks), keep: fv.keep}
+}
+
+// Clone returns a view of a clone of the table beneath, showing the same
+// columns.
+func (pv *ProjectionView) Clone(callbacks bool) Table {
+	return &ProjectionView{Table: pv.Table.Clone(callbacks), columns: append([]int(nil), pv.columns...)}
+}
+
+func (t *ATable) clone(callbacks bool) *ATable {
+	nt := &ATable{
+		ErrorContainer: &ErrorContainer{errors_: append(make([]error, 0, len(t.Errors())+10), t.Errors()...)},
+		propertyImpl:   t.propertyImpl.clone(),
+		title:          t.title,
+		subtitle:       t.subtitle,
+		totalsLabel:    t.totalsLabel,
+		nColumns:       t.nColumns,
+		columns:        make([]Column, len(t.columns), cap(t.columns)),
+	}
+	if t.columnNames != nil {
+		nt.columnNames = make(map[string]int, len(t.columnNames))
+		for name, i := range t.columnNames {
+			nt.columnNames[name] = i
+		}
+	}
+	if callbacks {
+		nt.tableItselfCallbacks = t.tableItselfCallbacks.clone()
+		nt.tableCellCallbacks = t.tableCellCallbacks.clone()
+		nt.tableRowAdditionCallbacks = t.tableRowAdditionCallbacks.clone()
+		nt.schemaSet = t.schemaSet
+		nt.computing = t.computing
+	} else {
+		if t.schemaSet {
+			nt.tableRowAdditionCallbacks.addTime = []PropertyCallback{schemaRowCallback{}}
+			nt.schemaSet = true
+		}
+		if t.computing {
+			nt.tableRowAdditionCallbacks.addTime = append(nt.tableRowAdditionCallbacks.addTime, computeCallback{})
+			nt.tableRowAdditionCallbacks.updateTime = []PropertyCallback{computeCallback{}}
+			nt.tableItselfCallbacks.preCellRenderTime = []PropertyCallback{computeCallback{}}
+			nt.computing = true
+		}
+	}
+	if t.formulas != nil {
+		nt.formulas = &formulaGraph{t: nt}
+		if !callbacks {
+			nt.tableItselfCallbacks.preCellRenderTime = append(nt.tableItselfCallbacks.preCellRenderTime, formulaCallback{})
+		}
+	}
+
+	for i := range t.columns {
+		c := &nt.columns[i]
+		c.Name = t.columns[i].Name
+		c.ofTable = nt
+		c.aggregator = t.columns[i].aggregator
+		c.spec = t.columns[i].spec
+		c.compute = t.columns[i].compute
+		c.propertyImpl = t.columns[i].propertyImpl.clone()
+		if callbacks {
+			c.cellCallbacks = t.columns[i].cellCallbacks.clone()
+			c.columnItselfCallbacks = t.columns[i].columnItselfCallbacks.clone()
+			c.aggregating = t.columns[i].aggregating
+			c.checking = t.columns[i].checking
+			continue
+		}
+		if t.columns[i].aggregating {
+			c.columnItselfCallbacks.postCellRenderTime = []PropertyCallback{totalsCallback{}}
+			c.aggregating = true
+		}
+		if t.columns[i].checking {
+			c.cellCallbacks.addTime = []PropertyCallback{schemaCallback{}}
+			c.cellCallbacks.updateTime = []PropertyCallback{schemaCallback{}}
+			c.checking = true
+		}
+	}
+
+	// Cells spanning rows refer to their anchor's row, so every row is
+	// copied before any cells are fixed up.
+	rows := make(map[*Row]*Row)
+	cloneRows := func(old []*Row) []*Row {
+		if old == nil {
+			return nil
+		}
+		rs := make([]*Row, len(old), cap(old))
+		for i := range old {
+			rs[i] = t.cloneRow(old[i], nt, callbacks, rows)
+		}
+		return rs
+	}
+	nt.headerRow = t.cloneRow(t.headerRow, nt, callbacks, rows)
+	nt.headerGroupRows = cloneRows(t.headerGroupRows)
+	nt.rows = cloneRows(t.rows)
+	nt.footerRows = cloneRows(t.footerRows)
+	nt.totalsRow = t.cloneRow(t.totalsRow, nt, callbacks, rows)
+	for _, r := range rows {
+		for i := range r.cells {
+			r.cells[i].inRow = r
+			if r.cells[i].spanFrom.row != nil {
+				r.cells[i].spanFrom.row = rows[r.cells[i].spanFrom.row]
+			}
+		}
+	}
+	return nt
+}
+
+// cloneRow copies a row of the table for the clone nt, recording it in rows;
+// the cells still need their rows fixing.
+func (t *ATable) cloneRow(r *Row, nt *ATable, callbacks bool, rows map[*Row]*Row) *Row {
+	if r == nil {
+		return nil
+	}
+	if nr, ok := rows[r]; ok {
+		return nr
+	}
+	nr := &Row{
+		propertyImpl: r.propertyImpl.clone(),
+		isSeparator:  r.isSeparator,
+		isSubtotal:   r.isSubtotal,
+		rowNum:       r.rowNum,
+	}
+	if r.inTable == t {
+		nr.inTable = nt
+	}
+	switch r.ErrorContainer {
+	case nil:
+	case t.ErrorContainer:
+		nr.ErrorContainer = nt.ErrorContainer
+	default:
+		nr.ErrorContainer = &ErrorContainer{errors_: append([]error(nil), r.Errors()...)}
+	}
+	if callbacks {
+		nr.rowCellCallbacks = r.rowCellCallbacks.clone()
+		nr.rowItselfCallbacks = r.rowItselfCallbacks.clone()
+	}
+	if r.cells != nil {
+		nr.cells = make([]Cell, len(r.cells), cap(r.cells))
+		for i := range r.cells {
+			c := r.cells[i]
+			c.propertyImpl = c.propertyImpl.clone()
+			if f, ok := c.raw.(*Formula); ok {
+				nf := *f
+				nf.row = nil
+				c.raw = &nf
+			}
+			if callbacks {
+				c.callbacks = c.callbacks.clone()
+			} else {
+				c.callbacks = callbackSet{}
+			}
+			nr.cells[i] = c
+		}
+	}
+	rows[r] = nr
+	return nr
+}
+
+// clone returns a copy of the properties which can be changed independently;
+// the chain is rebuilt because removing a property can edit it in place.
+func (pi propertyImpl) clone() propertyImpl {
+	var pairs []*valueProperty
+	tail := pi.properties
+	for {
+		v, ok := tail.(*valueProperty)
+		if !ok {
+			break
+		}
+		pairs = append(pairs, v)
+		tail = v.chain
+	}
+	for i := len(pairs) - 1; i >= 0; i-- {
+		tail = &valueProperty{chain: tail, key: pairs[i].key, val: pairs[i].val}
+	}
+	return propertyImpl{properties: tail}
+}
+
+// clone returns a copy of the callback set which can be added to independently.
+func (cs callbackSet) clone() callbackSet {
+	return callbackSet{
+		addTime:            append([]PropertyCallback(nil), cs.addTime...),
+		preCellRenderTime:  append([]PropertyCallback(nil), cs.preCellRenderTime...),
+		renderTime:         append([]PropertyCallback(nil), cs.renderTime...),
+		postCellRenderTime: append([]PropertyCallback(nil), cs.postCellRenderTime...),
+		updateTime:         append([]PropertyCallback(nil), cs.updateTime...),
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+	"go.pennock.tech/tabular/properties"
+	"go.pennock.tech/tabular/texttable"
+)
+
+func TestClone(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.SetTitle("Jobs")
+	populateJobs(tb)
+	tb.AddRowItems(tabular.NewSpanningCell("cleanup", 2, 2), 5)
+	tb.AddRowItems(6)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+	tb.SetProperty("marker", "table")
+	tb.Column(2).SetProperty("marker", "state")
+	tb.AllRows()[0].SetProperty("marker", "first")
+	tb.AllRows()[0].Cells()[0].SetProperty("marker", "build")
+	added := &countingCallback{}
+	T.ExpectSuccess(tb.RegisterPropertyCallback(tb, tabular.CB_AT_ADD, tabular.CB_ON_ROW, added), "register callback")
+
+	cl := tb.Clone(false)
+	T.Equal(cl.Title(), "Jobs", "title cloned")
+	T.Equal(headerNames(cl), headerNames(tb), "headers cloned")
+	T.Equal(firstColumn(cl), firstColumn(tb), "rows cloned")
+	T.Equal(cl.GetProperty("marker"), "table", "table property cloned")
+	T.Equal(cl.Column(2).GetProperty("marker"), "state", "column property cloned")
+	T.Equal(cl.AllRows()[0].GetProperty("marker"), "first", "row property cloned")
+	T.Equal(cl.AllRows()[0].Cells()[0].GetProperty("marker"), "build", "cell property cloned")
+	checkLocations(T, cl)
+
+	cl.Column(2).SetProperty(properties.Omit, true)
+	cl.Column(2).SetProperty("marker", nil)
+	cl.AllRows()[0].Cells()[0].SetProperty("marker", "changed")
+	cl.AddRowItems("extra", "done", 100)
+	T.Equal(tb.Column(2).GetProperty("marker"), "state", "original column property kept")
+	T.Equal(tb.AllRows()[0].Cells()[0].GetProperty("marker"), "build", "original cell property kept")
+	T.Equal(tb.NRows(), 9, "original rows kept")
+	T.Equal(added.count, 0, "callbacks not cloned")
+
+	rendered, err := csv.Wrap(tb).Render()
+	T.ExpectSuccess(err, "original rendered")
+	T.Equal(rendered, `"Job","State","Minutes"
+"build","done","4"
+"test","running","12"
+"lint","done","1"
+"deploy","queued","0"
+"notify","running","2"
+"cleanup","cleanup","5"
+"cleanup","cleanup","6"
+"Total","","30"
+`, "original unaffected by clone")
+
+	text := texttable.Wrap(cl)
+	_, err = text.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	rendered, err = text.Render()
+	T.ExpectSuccess(err, "clone rendered")
+	T.Equal(rendered, `        Jobs
++---------+---------+
+| Job     | Minutes |
++---------+---------+
+| build   | 4       |
+| test    | 12      |
++---------+---------+
+| lint    | 1       |
++---------+---------+
+| deploy  | 0       |
+| notify  | 2       |
+| cleanup | 5       |
+|         | 6       |
+| extra   | 100     |
++---------+---------+
+| Total   | 130     |
++---------+---------+
+`, "clone rendered with its own omissions and totals")
+
+	withCallbacks := tb.Clone(true)
+	withCallbacks.AddRowItems("again", "done", 1)
+	T.Equal(added.count, 1, "callbacks cloned")
+
+	running := tb.Filter(stateIs("running")).Clone(false)
+	tb.AddRowItems("retest", "running", 7)
+	T.Equal(firstColumn(running), []string{"test", "--", "notify"}, "view cloned over a clone")
+	T.Equal(tb.Errors(), nil, "no errors cloning")
+}
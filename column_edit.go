@@ -0,0 +1,316 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Editing the columns
+//
+// Columns can be inserted, deleted, moved and renamed.  Column numbers count
+// from 1, as for Column() and CellLocation.  Every row with cells is
+// rewritten: header groups, headers, the body and the footer.  A Column's
+// properties, callbacks and aggregator stay with it as it moves.
+//
+// Cells spanning columns are kept whole: a column inserted within a spanning
+// cell widens it, and deleting a column within one narrows it.  A column can
+// be moved within the region of a spanning cell, but not into or out of one.
+
+// ErrSpannedColumnMove is returned by MoveColumn if the move would take a
+// column into or out of the middle of a cell spanning columns.
+var ErrSpannedColumnMove = errors.New("can't move a column into or out of a cell spanning columns")
+
+// ErrorDuplicateColumn is returned by RenameColumn if the new name is already
+// the name of another column.
+type ErrorDuplicateColumn string
+
+func (e ErrorDuplicateColumn) Error() string {
+	return "column " + strconv.Quote(string(e)) + " already exists"
+}
+
+// InsertColumn adds a new column at the given column number, moving the
+// columns from there onwards right by one; a column number of NColumns()+1
+// adds a column on the right.  If the table has headers, then the header is
+// the new column's header.  The items are the new column's cells in the
+// body rows, top to bottom, skipping separators; rows beyond the items get
+// empty cells.  Rows in which the new column falls within a cell spanning
+// columns have that cell widened, with the row's item unused.
+func (t *ATable) InsertColumn(column int, header any, items ...any) error {
+	if column < 1 || column > t.nColumns+1 {
+		return ErrorColumnOutOfRange(column)
+	}
+	rows := t.rowsWithColumns()
+	cells := make([]Cell, len(rows))
+	next := 0
+	for n, row := range rows {
+		switch {
+		case row == t.headerRow:
+			cells[n] = cellForItem(header)
+		case row.inTable == t && next < len(items):
+			cells[n] = cellForItem(items[next])
+			next++
+		default:
+			cells[n] = NewCell(nil)
+		}
+	}
+	// Bottom-up, so that cells spanning rows are changed only after the rows
+	// which they cover.
+	for n := len(rows) - 1; n >= 0; n-- {
+		rows[n].spliceInCell(column-1, cells[n])
+	}
+
+	t.columns = append(t.columns, Column{})
+	copy(t.columns[column+1:], t.columns[column:])
+	t.columns[column] = Column{ofTable: t}
+	t.nColumns++
+	t.columnsRestructured()
+	return nil
+}
+
+// DeleteColumn removes the column with the given number from the table,
+// moving the columns to its right left by one.
+func (t *ATable) DeleteColumn(column int) error {
+	if column < 1 || column > t.nColumns {
+		return ErrorColumnOutOfRange(column)
+	}
+	rows := t.rowsWithColumns()
+	for n := len(rows) - 1; n >= 0; n-- {
+		rows[n].spliceOutCell(column - 1)
+	}
+
+	copy(t.columns[column:], t.columns[column+1:])
+	t.columns[len(t.columns)-1] = Column{}
+	t.columns = t.columns[:len(t.columns)-1]
+	t.nColumns--
+	t.columnsRestructured()
+	return nil
+}
+
+// MoveColumn moves the column with one number so that it has another, with
+// the columns between shifting to make room.  If the move would take the
+// column into or out of the middle of a cell spanning columns, in any row,
+// then ErrSpannedColumnMove is returned and the table is unchanged.
+func (t *ATable) MoveColumn(from, to int) error {
+	if from < 1 || from > t.nColumns {
+		return ErrorColumnOutOfRange(from)
+	}
+	if to < 1 || to > t.nColumns {
+		return ErrorColumnOutOfRange(to)
+	}
+	if from == to {
+		return nil
+	}
+	rows := t.rowsWithColumns()
+	for _, row := range rows {
+		if _, err := row.columnMoveAllowed(from-1, to-1); err != nil {
+			return err
+		}
+	}
+	for n := len(rows) - 1; n >= 0; n-- {
+		rows[n].moveCell(from-1, to-1)
+	}
+
+	moving := t.columns[from]
+	if from < to {
+		copy(t.columns[from:to], t.columns[from+1:to+1])
+	} else {
+		copy(t.columns[to+1:from+1], t.columns[to:from])
+	}
+	t.columns[to] = moving
+	t.columnsRestructured()
+	return nil
+}
+
+// RenameColumn changes the header of the column with the given name, so that
+// the column has a new name, which must not be the name of another column.
+// Any ColumnSpec set for the column by SetSchema is renamed too.
+func (t *ATable) RenameColumn(oldName, newName string) error {
+	if t.columnNames == nil {
+		return ErrNoColumnHeaders
+	}
+	columnNumber, ok := t.columnNames[oldName]
+	if !ok {
+		return ErrorNoSuchColumn(oldName)
+	}
+	if other, ok := t.columnNames[newName]; ok && other != columnNumber {
+		return ErrorDuplicateColumn(newName)
+	}
+	if spec := t.columns[columnNumber+1].spec; spec != nil {
+		renamed := *spec
+		renamed.Name = newName
+		t.columns[columnNumber+1].spec = &renamed
+	}
+	cell := &t.headerRow.cells[columnNumber]
+	cell.raw = newName
+	cell.Update()
+	t.rebuildColumnNames()
+	return nil
+}
+
+// rowsWithColumns returns every row of the table which has cells in its
+// columns, top to bottom, other than the totals row, which is rebuilt after
+// restructuring.
+func (t *ATable) rowsWithColumns() []*Row {
+	rows := make([]*Row, 0, len(t.headerGroupRows)+1+len(t.rows)+len(t.footerRows))
+	rows = append(rows, t.headerGroupRows...)
+	if t.headerRow != nil {
+		rows = append(rows, t.headerRow)
+	}
+	for _, row := range t.rows {
+		if !row.isSeparator {
+			rows = append(rows, row)
+		}
+	}
+	return append(rows, t.footerRows...)
+}
+
+// columnsRestructured brings everything derived from the order of the
+// columns back up to date.
+func (t *ATable) columnsRestructured() {
+	for _, row := range t.rowsWithColumns() {
+		row.renumberCells()
+	}
+	if t.totalsRow != nil {
+		t.totalsRow.cells = t.totalsRow.cells[:0]
+		t.sizeTotalsRow()
+	}
+	t.rebuildColumnNames()
+	t.recomputeSpans()
+}
+
+// rebuildColumnNames indexes the columns by the names in the header row.
+func (t *ATable) rebuildColumnNames() {
+	if t.headerRow == nil {
+		return
+	}
+	hr := t.headerRow
+	columnNames := make(map[string]int, len(hr.cells))
+	for i := range hr.cells {
+		if hr.cells[i].SpanCovered() {
+			continue
+		}
+		columnNames[hr.cells[i].String()] = i
+	}
+	t.columnNames = columnNames
+	t.layout++
+}
+
+// renumberCells sets each cell's back-pointer and column number, after cells
+// have been moved around within the row.
+func (r *Row) renumberCells() {
+	for i := range r.cells {
+		r.cells[i].inRow = r
+		r.cells[i].columnNum = i + 1
+	}
+}
+
+// columnRegion returns the 0-based index of the first column and the count
+// of columns covered by whatever cell is shown at index i of the row.
+func (r *Row) columnRegion(i int) (start, count int) {
+	anchor := r.cells[i].SpanAnchor()
+	if anchor.span.columns < 2 {
+		return i, 1
+	}
+	return anchor.columnNum - 1, anchor.span.columns
+}
+
+// spliceInCell inserts a cell for a new column at 0-based index i of the
+// row; if that is within a cell spanning columns, then the spanning cell is
+// widened and a placeholder inserted instead.
+func (r *Row) spliceInCell(i int, c Cell) {
+	if i > len(r.cells) {
+		if c.Empty() {
+			return
+		}
+		for len(r.cells) < i {
+			r.cells = append(r.cells, NewCell(nil))
+		}
+	}
+	if i < len(r.cells) {
+		if start, _ := r.columnRegion(i); start < i {
+			origin := r.cells[i].spanFrom
+			if origin.row == r {
+				r.cells[start].span.columns++
+			}
+			c = newPlaceholderCell(origin.row, origin.column)
+		}
+	}
+	r.cells = append(r.cells, Cell{})
+	copy(r.cells[i+1:], r.cells[i:])
+	r.cells[i] = c
+}
+
+// spliceOutCell removes the cell at 0-based index i of the row; if that is
+// within a cell spanning columns, then the spanning cell is narrowed.
+func (r *Row) spliceOutCell(i int) {
+	if i >= len(r.cells) {
+		return
+	}
+	if start, count := r.columnRegion(i); count > 1 {
+		if anchor := r.cells[i].SpanAnchor(); anchor.inRow == r {
+			anchor.span.columns--
+		}
+		if i == start && i+1 < len(r.cells) {
+			// keep the anchor, and so the content, at the start
+			i++
+		}
+	}
+	copy(r.cells[i:], r.cells[i+1:])
+	r.cells = r.cells[:len(r.cells)-1]
+}
+
+// columnMoveAllowed checks whether the cell at 0-based index from of the row
+// can be moved to index to.  It reports whether the move is within a cell
+// spanning columns, so that the row does not change.
+func (r *Row) columnMoveAllowed(from, to int) (within bool, err error) {
+	if from >= len(r.cells) && to >= len(r.cells) {
+		return false, nil
+	}
+	if from < len(r.cells) {
+		if start, count := r.columnRegion(from); count > 1 {
+			if start <= to && to < start+count {
+				return true, nil
+			}
+			return false, ErrSpannedColumnMove
+		}
+	}
+	// the neighbours between which the cell will land
+	left, right := to-1, to
+	if to > from {
+		left, right = to, to+1
+	}
+	if left < 0 || right >= len(r.cells) {
+		return false, nil
+	}
+	ls, lc := r.columnRegion(left)
+	rs, _ := r.columnRegion(right)
+	if lc > 1 && ls == rs {
+		return false, ErrSpannedColumnMove
+	}
+	return false, nil
+}
+
+// moveCell moves the cell at 0-based index from of the row to index to,
+// padding a short row as needed; columnMoveAllowed must have been checked.
+func (r *Row) moveCell(from, to int) {
+	if within, _ := r.columnMoveAllowed(from, to); within {
+		return
+	}
+	if from >= len(r.cells) && to >= len(r.cells) {
+		return
+	}
+	for len(r.cells) <= max(from, to) {
+		r.cells = append(r.cells, NewCell(nil))
+	}
+	moving := r.cells[from]
+	if from < to {
+		copy(r.cells[from:to], r.cells[from+1:to+1])
+	} else {
+		copy(r.cells[to+1:from+1], r.cells[to:from])
+	}
+	r.cells[to] = moving
+}
@@ -0,0 +1,142 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/texttable"
+)
+
+func headerNames(tb tabular.Table) []string {
+	headers := tb.Headers()
+	r := make([]string, len(headers))
+	for i := range headers {
+		r[i] = headers[i].String()
+	}
+	return r
+}
+
+func TestColumnEditing(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("name", "size")
+	tb.AddRowItems("a", 1)
+	tb.AddSeparator()
+	tb.AddRowItems("b", 2)
+	tb.AddFooterItems("all", 3)
+	tb.Column(2).SetProperty("marker", "size column")
+
+	T.ExpectSuccess(tb.InsertColumn(2, "owner", "alice", "bob"), "insert column")
+	T.Equal(tb.NColumns(), 3, "column count grew")
+	T.Equal(headerNames(tb), []string{"name", "owner", "size"}, "header inserted")
+	T.Equal(tb.AllRows()[2].Cells()[1].String(), "bob", "items fill body rows, skipping separators")
+	T.Equal(tb.FooterRows()[0].Cells()[2].String(), "3", "footer rows shifted")
+	T.Equal(tb.Column(3).GetProperty("marker"), "size column", "properties moved with column")
+	col, err := tb.ColumnNamed("size")
+	T.ExpectSuccess(err, "column found by name after insertion")
+	T.Equal(col, tb.Column(3), "name index rebuilt")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.MoveColumn(3, 1), "move column")
+	T.Equal(headerNames(tb), []string{"size", "name", "owner"}, "column moved")
+	T.Equal(tb.Column(1).GetProperty("marker"), "size column", "properties moved with column")
+	T.Equal(tb.AllRows()[0].Cells()[0].String(), "1", "body cells moved")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.RenameColumn("owner", "user"), "rename column")
+	T.Equal(headerNames(tb), []string{"size", "name", "user"}, "column renamed")
+	_, err = tb.ColumnNamed("owner")
+	T.ExpectError(err, "old name gone")
+	T.Equal(tb.RenameColumn("owner", "x"), tabular.ErrorNoSuchColumn("owner"), "can't rename a missing column")
+	T.Equal(tb.RenameColumn("user", "size"), tabular.ErrorDuplicateColumn("size"), "can't rename onto another column")
+	T.Equal(headerNames(tb), []string{"size", "name", "user"}, "duplicate rename changed nothing")
+	T.ExpectSuccess(tb.RenameColumn("user", "user"), "rename column to its own name")
+
+	T.ExpectSuccess(tb.DeleteColumn(2), "delete column")
+	T.Equal(headerNames(tb), []string{"size", "user"}, "column deleted")
+	T.Equal(tb.NColumns(), 2, "column count shrank")
+	T.Equal(tb.AllRows()[2].Cells()[1].String(), "bob", "body cells shifted")
+	checkLocations(T, tb)
+
+	T.Equal(tb.InsertColumn(4, "x"), tabular.ErrorColumnOutOfRange(4), "insert out of range")
+	T.Equal(tb.DeleteColumn(0), tabular.ErrorColumnOutOfRange(0), "delete out of range")
+	T.Equal(tb.MoveColumn(1, 3), tabular.ErrorColumnOutOfRange(3), "move out of range")
+	T.Equal(tb.Errors(), nil, "no errors editing columns")
+}
+
+func TestColumnEditingSpans(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := texttable.New()
+	tb.AddHeaderGroups(tabular.HeaderGroup{Columns: 1}, tabular.HeaderGroup{Name: "Latency", Columns: 2})
+	tb.AddHeaders("host", "p50", "p99")
+	tb.AddRowItems(tabular.NewSpanningCell("alpha", 1, 2), 3, 40)
+	tb.AddRowItems(4, 50)
+	tb.AddRowItems("beta", tabular.NewSpanningCell("down", 2, 1))
+	tb.SetTotalsLabel("max")
+	tb.Column(3).SetAggregator(tabular.AGG_MAX)
+
+	T.ExpectSuccess(tb.InsertColumn(3, "p95", 9, 12), "insert column within group")
+	T.Equal(tb.MoveColumn(2, 1), tabular.ErrSpannedColumnMove, "can't move a column out of a group")
+	T.Equal(tb.MoveColumn(1, 3), tabular.ErrSpannedColumnMove, "can't move a column into a group")
+	T.ExpectSuccess(tb.MoveColumn(4, 2), "move column within group")
+	should := strings.TrimLeft(`
+┏━━━━━━━┳━━━━━━━━━━━━━━━━━┓
+┃       ┃ Latency         ┃
+┣━━━━━━━╋━━━━━┳━━━━━┳━━━━━┫
+┃ host  ┃ p99 ┃ p50 ┃ p95 ┃
+┣━━━━━━━╇━━━━━╇━━━━━╇━━━━━┫
+┃ alpha │ 40  │ 3   │ 9   ┃
+┃       │ 50  │ 4   │ 12  ┃
+┃ beta  │ down            ┃
+┣━━━━━━━┿━━━━━┯━━━━━┯━━━━━┫
+┃ max   │ 50  │     │     ┃
+┗━━━━━━━┷━━━━━┷━━━━━┷━━━━━┛
+`, "\n")
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "restructured table rendered")
+	T.Equal(rendered, should, "restructured table rendered correctly")
+
+	// Deleting the only aggregated column drops the totals row.
+	T.ExpectSuccess(tb.DeleteColumn(2), "delete column within spans")
+	T.ExpectSuccess(tb.DeleteColumn(1), "delete column with a cell spanning rows")
+	should = strings.TrimLeft(`
+┏━━━━━━━━━━━┓
+┃ Latency   ┃
+┣━━━━━┳━━━━━┫
+┃ p50 ┃ p95 ┃
+┣━━━━━╇━━━━━┫
+┃ 3   │ 9   ┃
+┃ 4   │ 12  ┃
+┃ down      ┃
+┗━━━━━━━━━━━┛
+`, "\n")
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "table with deleted columns rendered")
+	T.Equal(rendered, should, "table with deleted columns rendered correctly")
+	T.Equal(tb.Errors(), nil, "no errors editing columns with spans")
+}
+
+func TestRenameColumnWithSchema(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.SetSchema(tabular.Schema{{Name: "Host", Required: true}, {Name: "Port"}})
+	T.ExpectSuccess(tb.RenameColumn("Host", "Server"), "rename column with a spec")
+	T.Equal(tb.Column(1).Spec().Name, "Server", "spec renamed")
+	tb.AddRowItems(nil, 22)
+	T.Equal(tb.Errors(), []error{
+		tabular.ErrorSchemaViolation{Location: tabular.CellLocation{Row: 1, Column: 1}, Column: "Server", Problem: "required but empty"},
+	}, "violation reports the new name")
+}
@@ -0,0 +1,104 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"reflect"
+)
+
+// AddComputedColumn appends a column, headed by name, whose cell in each row
+// of the body is the item returned by fn for that row.  The cells are filled
+// for the rows already in the table and for each row added later, and are
+// computed again when another cell of the row is changed by Cell.SetItem, and
+// for every row when the table is rendered, so that fn can depend upon the
+// rest of the table, as for a percentage of a total.  Separators and subtotal
+// rows are left alone, as are rows where the column is covered by a cell
+// spanning columns.
+//
+// The column is an ordinary column otherwise, and can be moved, aggregated and
+// sorted upon, and read by formulas, which are evaluated again when a cell
+// they read is computed anew with a different item.  Any error accumulates in
+// the table, which is returned.
+func (t *ATable) AddComputedColumn(name string, fn func(*Row) any) Table {
+	n := t.nColumns + 1
+	if err := t.InsertColumn(n, name); err != nil {
+		t.AddError(err)
+		return t
+	}
+	t.columns[n].compute = fn
+	if !t.computing {
+		// Registered just once; the callbacks find the computed columns when
+		// invoked.
+		if err := t.RegisterPropertyCallback(t, CB_AT_ADD, CB_ON_ROW, computeCallback{}); err != nil {
+			t.AddError(err)
+			return t
+		}
+		if err := t.RegisterPropertyCallback(t, CB_AT_UPDATE, CB_ON_ROW, computeCallback{}); err != nil {
+			t.AddError(err)
+			return t
+		}
+		if err := t.RegisterPropertyCallback(t, CB_AT_RENDER_PRECELL, CB_ON_ITSELF, computeCallback{}); err != nil {
+			t.AddError(err)
+			return t
+		}
+		t.computing = true
+	}
+	for _, row := range t.rows {
+		t.computeRow(row)
+	}
+	return t
+}
+
+// computeCallback is the addition-time and update-time callback which fills
+// the computed cells of a row of the body, and the render-time callback which
+// fills those of every row.
+type computeCallback struct{}
+
+func (computeCallback) UpdateProperties(owner PropertyOwner) error {
+	switch o := owner.(type) {
+	case *Row:
+		if o.inTable != nil {
+			o.inTable.computeRow(o)
+		}
+	case *ATable:
+		// Formulas are brought up to date both before and after, since fn
+		// might read them and they might read the computed columns.
+		o.Recalculate()
+		for _, row := range o.rows {
+			o.computeRow(row)
+		}
+		o.Recalculate()
+	}
+	return nil
+}
+
+// computeRow fills the cells of a row of the body in the computed columns,
+// first extending a short row with empty cells, and tells any formulas of
+// items which change.
+func (t *ATable) computeRow(r *Row) {
+	if r.cells == nil || r.isSubtotal || r.inTable != t {
+		return
+	}
+	for n := 1; n <= t.nColumns; n++ {
+		fn := t.columns[n].compute
+		if fn == nil {
+			continue
+		}
+		for len(r.cells) < n {
+			r.addCell(NewCell(nil))
+		}
+		c := &r.cells[n-1]
+		if columns, _ := c.Span(); c.SpanCovered() || columns > 1 {
+			continue
+		}
+		item := fn(r)
+		if reflect.DeepEqual(item, c.raw) {
+			continue
+		}
+		c.raw = item
+		c.Update()
+		t.formulaChanged(c)
+	}
+}
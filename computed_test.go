@@ -0,0 +1,110 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+)
+
+func TestAddComputedColumn(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaders("Job", "State", "Minutes")
+	tb.AddRowItems("build", "done", 4)
+	tb.AddRowItems("test", "running", 12)
+	tb.AddRowItems("lint", "done", 1)
+	minutes := func(r *tabular.Row) int {
+		c, err := r.CellNamed("Minutes")
+		if err != nil {
+			return 0
+		}
+		n, _ := c.Item().(int)
+		return n
+	}
+	tb.AddComputedColumn("Hours", func(r *tabular.Row) any {
+		return fmt.Sprintf("%.2f", float64(minutes(r))/60)
+	})
+	tb.AddComputedColumn("Share", func(r *tabular.Row) any {
+		total := 0
+		for _, row := range tb.AllRows() {
+			if row.Cells() != nil {
+				total += minutes(row)
+			}
+		}
+		if total == 0 {
+			return nil
+		}
+		return fmt.Sprintf("%d%%", 100*minutes(r)/total)
+	})
+	T.Equal(headerNames(tb), []string{"Job", "State", "Minutes", "Hours", "Share"}, "computed columns appended")
+	T.Equal(columnStrings(tb, 4), []string{"0.07", "0.20", "0.02"}, "existing rows filled")
+
+	tb.AddRowItems("report", "done", 15)
+	tb.AddRowItems("archive")
+	T.Equal(columnStrings(tb, 4)[3:], []string{"0.25", "0.00"}, "new rows filled")
+
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 2, Column: 3}, 18), "change a cell the column depends upon")
+	T.Equal(tb.AllRows()[1].Cells()[3].String(), "0.30", "row computed again on update")
+
+	tb.AddSeparator()
+	tb.AddRowItems(tabular.NewSpanningCell("paused", 5, 1))
+	clone := tb.Clone(false)
+	clone.AddRowItems("extra", "done", 60)
+	T.Equal(clone.AllRows()[7].Cells()[3].String(), "1.00", "clone keeps computing")
+
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "render computed columns")
+	T.Equal(rendered, `"Job","State","Minutes","Hours","Share"
+"build","done","4","0.07","10%"
+"test","running","18","0.30","47%"
+"lint","done","1","0.02","2%"
+"report","done","15","0.25","39%"
+"archive","","","0.00","0%"
+"paused","paused","paused","paused","paused"
+`, "shares of the total computed at render")
+	T.Equal(tb.Errors(), nil, "no errors")
+}
+
+func TestComputedColumnWithFormulas(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaders("X", "F")
+	tb.AddRowItems(3, tabular.MustParseFormula("{Double} + 1"))
+	tb.AddComputedColumn("Double", func(r *tabular.Row) any {
+		c, err := r.CellNamed("X")
+		if err != nil {
+			return nil
+		}
+		n, _ := c.Item().(int)
+		return 2 * n
+	})
+	tb.AddRowItems(4, tabular.MustParseFormula("{Double} + 1"))
+
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "render formulas reading a computed column")
+	T.Equal(rendered, `"X","F","Double"
+"3","7","6"
+"4","9","8"
+`, "formulas read computed cells")
+
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 1, Column: 1}, 5), "change an input of the computed column")
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "render after change")
+	T.Equal(rendered, `"X","F","Double"
+"5","11","10"
+"4","9","8"
+`, "formula evaluated again when the computed cell changes")
+	T.Equal(tb.Errors(), nil, "no errors")
+}
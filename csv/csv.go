@@ -1,4 +1,4 @@
-// Copyright © 2016,2025 Pennock Tech, LLC.
+// Copyright © 2016,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -14,10 +14,19 @@ import (
 )
 
 // A CSVTable wraps a tabular.Table to act as a render control for CSV output.
+//
+// CSV has no notion of merged cells, so a cell spanning several columns or
+// rows has its value repeated in every field which it covers; this keeps
+// each record self-contained for whatever consumes the CSV.  Likewise, the
+// names of any header groups are joined onto the front of each column header.
+// Footer rows, including any totals row, are emitted as records after the
+// body.  Subtotal rows, as made by GroupBy, are not emitted, so that every
+// record of the body is one row of data.
 type CSVTable struct {
 	tabular.Table
 
-	fieldSeparator string
+	fieldSeparator       string
+	headerGroupSeparator string
 	// TODO: any output style controls here, to deviate from RFC4180 (eg,
 	// tab-output, only-quote-if-needed, other-escaping.
 }
@@ -25,11 +34,21 @@ type CSVTable struct {
 // Wrap returns a CSVTable rendering object for the given tabular.Table.
 func Wrap(t tabular.Table) *CSVTable {
 	return &CSVTable{
-		Table:          t,
-		fieldSeparator: ",",
+		Table:                t,
+		fieldSeparator:       ",",
+		headerGroupSeparator: tabular.DEFAULT_HEADER_GROUP_SEPARATOR,
 	}
 }
 
+// SetHeaderGroupSeparator sets the text put between the names of header
+// groups and the column header, when they are joined together to make the
+// header record; CSV has only one header record.  The CSVTable is returned,
+// to permit chaining.
+func (ct *CSVTable) SetHeaderGroupSeparator(sep string) *CSVTable {
+	ct.headerGroupSeparator = sep
+	return ct
+}
+
 // New returns a CSVTable with a new Table inside it, access via .Table
 // or just use the interface methods on the CSVTable.
 func New() *CSVTable {
@@ -101,7 +120,7 @@ func (ct *CSVTable) RenderTo(w io.Writer) error {
 	}
 	displayColumnCount -= omittedCount
 
-	headers := ct.Headers()
+	headers := tabular.FlattenHeaders(ct, ct.headerGroupSeparator)
 	if headers != nil {
 		if err = ct.emitRow(w, displayColumnCount, omitColumns, headers); err != nil {
 			return err
@@ -115,13 +134,18 @@ func (ct *CSVTable) RenderTo(w io.Writer) error {
 		if skipRow {
 			continue
 		}
-		if r.IsSeparator() {
+		if r.IsSeparator() || r.IsSubtotal() {
 			continue
 		}
 		if err = ct.emitRow(w, displayColumnCount, omitColumns, r.Cells()); err != nil {
 			return err
 		}
 	}
+	for _, r := range ct.FooterRows() {
+		if err = ct.emitRow(w, displayColumnCount, omitColumns, r.Cells()); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -169,7 +193,7 @@ func (ct *CSVTable) emitRow(w io.Writer, displayColumnCount int, omitColumns []b
 		if shown {
 			line.WriteString(ct.fieldSeparator)
 		}
-		line.WriteString(ct.csvEscape(cells[i].String()))
+		line.WriteString(ct.csvEscape(cells[i].SpanAnchor().String()))
 		shown = true
 	}
 	for i++; i < displayColumnCount; i++ {
@@ -155,3 +155,82 @@ func TestSingleColumnCSV(t *testing.T) {
 	T.ExpectSuccess(err, "single-column table renders without errors")
 	T.Equal(have, should, "got correct single-column output")
 }
+
+func TestSpansCSV(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaders(tabular.NewSpanningCell("latency", 2, 1), "unit")
+	tb.AddRowItems(tabular.NewSpanningCell("p50", 1, 2), 3, "ms")
+	tb.AddRowItems(4, "ms")
+	tb.AddRowItems(tabular.NewSpanningCell("none", 2, 1), "-")
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := `"latency","latency","unit"
+"p50","3","ms"
+"p50","4","ms"
+"none","none","-"
+`
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "spanning table renders without errors")
+	T.Equal(have, should, "spanned values repeated in every field covered")
+}
+
+func TestHeaderGroupsCSV(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaderGroups(tabular.HeaderGroup{Columns: 1}, tabular.HeaderGroup{Name: "Latency", Columns: 2})
+	tb.AddHeaders("host", "p50", "p99")
+	tb.AddRowItems("alpha", 3, 40)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "grouped table renders without errors")
+	T.Equal(have, `"host","Latency p50","Latency p99"`+"\n"+`"alpha","3","40"`+"\n", "group names joined onto headers")
+
+	have, err = tb.SetHeaderGroupSeparator(": ").Render()
+	T.ExpectSuccess(err, "grouped table renders without errors, custom separator")
+	T.Equal(have, `"host","Latency: p50","Latency: p99"`+"\n"+`"alpha","3","40"`+"\n", "group names joined with custom separator")
+}
+
+func TestFooterCSV(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaders("item", "qty")
+	tb.AddRowItems("apple", 3)
+	tb.AddRowItems("pear", 2)
+	tb.SetTotalsLabel("Total")
+	tb.Column(2).SetAggregator(tabular.AGG_SUM)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "table with totals renders without errors")
+	T.Equal(have, `"item","qty"`+"\n"+`"apple","3"`+"\n"+`"pear","2"`+"\n"+`"Total","5"`+"\n", "totals record after the body")
+}
+
+func TestSubtotalsCSV(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaders("region", "qty")
+	tb.AddRowItems("east", 3)
+	tb.AddRowItems("west", 2)
+	tb.AddRowItems("east", 4)
+	T.ExpectSuccess(tb.GroupBy(tabular.SortKey{Name: "region"}, tabular.GroupTotal{Name: "qty", Aggregator: tabular.AGG_SUM}), "group with subtotals")
+	tb.SetTotalsLabel("Total")
+
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "grouped table renders without errors")
+	T.Equal(have, `"region","qty"
+"east","3"
+"east","4"
+"west","2"
+"Total","9"
+`, "subtotal rows not emitted as records")
+}
@@ -0,0 +1,64 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+)
+
+type endpoint struct {
+	host string
+	port int
+}
+
+func (e endpoint) Fields() []string { return []string{e.host, "port " + e.String()} }
+func (e endpoint) String() string   { return e.host }
+
+type endpointColumns struct{}
+
+func (endpointColumns) Fields() []string { return []string{"Host", "Port"} }
+
+type usage struct {
+	cpu, mem int
+}
+
+func (u usage) AnonFields() []any { return []any{u.cpu, u.mem} }
+
+// Fields is shadowed by AnonFields.
+func (u usage) Fields() []string { return []string{"unused"} }
+
+func TestFielders(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	tb.AddHeaders("Name", endpointColumns{}, "CPU", "Mem")
+	T.Equal(headerNames(tb), []string{"Name", "Host", "Port", "CPU", "Mem"}, "headers from a Fielder")
+	_, err := tb.ColumnNamed("Port")
+	T.ExpectSuccess(err, "Fielder header names columns")
+
+	tb.AddRowItems("web", endpoint{"web1", 80}, usage{12, 300})
+	tb.AddRow(tabular.NewRow().Add(tabular.NewSpanningCell("db", 1, 1)).Add(tabular.NewCell(endpoint{"db1", 5432})).Add(tabular.NewSpanningCell("idle", 2, 1)))
+	T.ExpectSuccess(tb.InsertRowAt(1, tabular.NewRow().Add(tabular.NewCell("first")).Add(tabular.NewCell(usage{1, 2}))), "insert row")
+	tb.AddFooterItems("Total", endpoint{"-", 0}, usage{13, 302})
+	checkLocations(T, tb)
+	T.Equal(tb.NColumns(), 5, "no extra columns")
+	T.Equal(tb.AllRows()[1].Cells()[3].Item(), 12, "AnonFielder items kept as they are")
+
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "expanded rows rendered")
+	T.Equal(rendered, `"Name","Host","Port","CPU","Mem"
+"first","1","2","",""
+"web","web1","port web1","12","300"
+"db","db1","port db1","idle","idle"
+"Total","-","port -","13","302"
+`, "Fielders and AnonFielders expanded into cells")
+	T.Equal(tb.Errors(), nil, "no errors expanding fielders")
+}
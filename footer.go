@@ -0,0 +1,425 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.pennock.tech/tabular/properties"
+)
+
+// Footer rows
+//
+// A table can have rows in a footer, beneath the body.  These are kept apart
+// from the body rows, so are not counted by NRows, nor moved by sorting, and
+// renderers show them set apart from the body where they can.
+//
+// A column can have an Aggregator, to summarise the column's body cells;
+// once any column has one, the table gains a totals row as its last footer
+// row, with each aggregated column's cell computed afresh at render time by
+// a CB_AT_RENDER_POSTCELL callback on the column.  So the totals always
+// reflect the body as rendered, including any rows added after the
+// aggregator was set.
+
+// ErrAggregateDefaultsColumn is recorded if an aggregator is set upon column
+// 0, which holds defaults for the other columns rather than any cells.
+var ErrAggregateDefaultsColumn = errors.New("can't aggregate the defaults column")
+
+// AddFooterRow adds a *Row to the footer of the table, beneath any previous
+// footer rows, and returns the table.  Cells in a footer row can span
+// columns but not rows.
+func (t *ATable) AddFooterRow(row *Row) Table {
+	if row.cells == nil {
+		t.AddError(errors.New("can't add a non-cell row to the footer"))
+		return t
+	}
+	es := row.Errors()
+	if es != nil {
+		t.AddErrorList(es)
+	}
+	row.ErrorContainer = t.ErrorContainer
+	row.expandFielders()
+	t.resizeColumnsAtLeast(len(row.cells))
+	markColumnSpans(row)
+	t.footerRows = append(t.footerRows, row)
+
+	invokePropertyCallbacks(row.rowItselfCallbacks, CB_AT_ADD, row, t.ErrorContainer)
+	t.invokeOuterRowAddCallbacks(row)
+	return t
+}
+
+// AddFooterItems creates a row from the passed items and adds it to the
+// footer of the table, returning the table.  Any item which is a Cell is
+// added as that cell.
+func (t *ATable) AddFooterItems(items ...any) Table {
+	r := NewRowWithCapacity(len(items))
+	for i := range items {
+		r.Add(cellForItem(items[i]))
+	}
+	return t.AddFooterRow(r)
+}
+
+// FooterRows returns the rows of the footer, top-most first, with the totals
+// row last if any column has an aggregator; if there are no footer rows then
+// nil is returned.
+func (t *ATable) FooterRows() []*Row {
+	totals := t.TotalsRow()
+	if t.footerRows == nil && totals == nil {
+		return nil
+	}
+	rr := make([]*Row, len(t.footerRows), len(t.footerRows)+1)
+	copy(rr, t.footerRows)
+	if totals != nil {
+		rr = append(rr, totals)
+	}
+	return rr
+}
+
+// TotalsRow returns the footer row holding the results of the column
+// aggregators, or nil if no column has an aggregator.  The cells of
+// aggregated columns are only filled in by InvokeRenderCallbacks.
+func (t *ATable) TotalsRow() *Row {
+	if t.totalsRow == nil {
+		return nil
+	}
+	for i := 1; i <= t.nColumns; i++ {
+		if t.columns[i].aggregator != nil {
+			return t.totalsRow
+		}
+	}
+	return nil
+}
+
+// SetTotalsLabel sets an item to show in the first column of the totals row,
+// such as "Total", if that column has no aggregator of its own.  The table
+// is returned.
+func (t *ATable) SetTotalsLabel(label any) Table {
+	t.totalsLabel = label
+	if t.totalsRow != nil && len(t.totalsRow.cells) > 0 && t.columns[1].aggregator == nil {
+		t.totalsRow.setCell(0, cellForItem(label))
+	}
+	return t
+}
+
+// sizeTotalsRow makes sure that the totals row exists, and has a cell for
+// every column.
+func (t *ATable) sizeTotalsRow() {
+	if t.totalsRow == nil {
+		t.totalsRow = NewRowWithCapacity(t.nColumns)
+		t.totalsRow.ErrorContainer = t.ErrorContainer
+	}
+	r := t.totalsRow
+	for len(r.cells) < t.nColumns {
+		if len(r.cells) == 0 {
+			r.addCell(cellForItem(t.totalsLabel))
+		} else {
+			r.addCell(NewCell(nil))
+		}
+	}
+}
+
+// setCell replaces the cell at the given 0-based index of the row.
+func (r *Row) setCell(i int, c Cell) {
+	c.inRow = r
+	c.columnNum = i + 1
+	c.span = cellSpan{}
+	c.spanFrom = spanOrigin{}
+	r.cells[i] = c
+}
+
+// An Aggregator summarises the body cells of one column, for the totals row
+// of the table's footer.  It is passed the cells of the column from each
+// body row which has one and which is not omitted from display, skipping
+// separators and positions covered by a cell spanning over them.  The result
+// is held in a new cell.
+type Aggregator interface {
+	Aggregate(cells []*Cell) any
+}
+
+// AggregatorFunc adapts a function to be an Aggregator.
+type AggregatorFunc func(cells []*Cell) any
+
+// Aggregate calls f(cells).
+func (f AggregatorFunc) Aggregate(cells []*Cell) any { return f(cells) }
+
+type builtinAggregator int
+
+// These constants are the built-in Aggregators.  The numeric aggregators
+// use those cells holding numbers, or strings which parse as numbers, and
+// give an empty cell if there are none.  A sum is an int64 if every number
+// summed is an integer, else a float64.  Min and max give the item from the
+// winning cell: they compare the numbers if there are any, else compare the
+// non-empty cells as for sorting.
+const (
+	AGG_SUM builtinAggregator = iota + 1
+	AGG_MEAN
+	AGG_MIN
+	AGG_MAX
+	AGG_COUNT           // how many body rows have a cell in the column
+	AGG_COUNT_NON_EMPTY // how many of those cells are not empty
+)
+
+func (a builtinAggregator) String() string {
+	switch a {
+	case AGG_SUM:
+		return "sum"
+	case AGG_MEAN:
+		return "mean"
+	case AGG_MIN:
+		return "min"
+	case AGG_MAX:
+		return "max"
+	case AGG_COUNT:
+		return "count"
+	case AGG_COUNT_NON_EMPTY:
+		return "count-non-empty"
+	default:
+		panic("unhandled aggregator for String")
+	}
+}
+
+func (a builtinAggregator) Aggregate(cells []*Cell) any {
+	switch a {
+	case AGG_SUM, AGG_MEAN:
+		var (
+			isum    int64
+			fsum    float64
+			isFloat bool
+			count   int
+		)
+		for _, c := range cells {
+			n, ok := cellNumber(c)
+			if !ok {
+				continue
+			}
+			count++
+			if n.isFloat && !isFloat {
+				isFloat = true
+				fsum = float64(isum)
+			}
+			if isFloat {
+				fsum += n.float()
+			} else {
+				isum += n.i
+			}
+		}
+		if count == 0 {
+			return nil
+		}
+		if a == AGG_MEAN {
+			if !isFloat {
+				fsum = float64(isum)
+			}
+			return fsum / float64(count)
+		}
+		if isFloat {
+			return fsum
+		}
+		return isum
+	case AGG_MIN, AGG_MAX:
+		// Numbers win over anything else, so that numbers held as strings
+		// are compared as numbers.
+		var (
+			best    *Cell
+			bestN   cellNumberValue
+			bestIsN bool
+		)
+		for _, c := range cells {
+			if c.Empty() {
+				continue
+			}
+			n, isN := cellNumber(c)
+			switch {
+			case best == nil, isN && !bestIsN:
+			case isN && bestIsN:
+				if !(a == AGG_MIN && n.less(bestN)) && !(a == AGG_MAX && bestN.less(n)) {
+					continue
+				}
+			case bestIsN:
+				continue
+			default:
+				if !(a == AGG_MIN && c.LessThan(best)) && !(a == AGG_MAX && best.LessThan(c)) {
+					continue
+				}
+			}
+			best, bestN, bestIsN = c, n, isN
+		}
+		if best == nil {
+			return nil
+		}
+		return best.Item()
+	case AGG_COUNT:
+		return len(cells)
+	case AGG_COUNT_NON_EMPTY:
+		count := 0
+		for _, c := range cells {
+			if !c.Empty() {
+				count++
+			}
+		}
+		return count
+	default:
+		panic("unhandled aggregator in Aggregate")
+	}
+}
+
+// cellNumberValue holds the numeric value of a cell, for aggregating.
+type cellNumberValue struct {
+	i       int64
+	f       float64
+	isFloat bool
+}
+
+func (n cellNumberValue) less(m cellNumberValue) bool {
+	if !n.isFloat && !m.isFloat {
+		return n.i < m.i
+	}
+	return n.float() < m.float()
+}
+
+func (n cellNumberValue) float() float64 {
+	if n.isFloat {
+		return n.f
+	}
+	return float64(n.i)
+}
+
+// cellNumber returns the numeric value of the item in a cell, if it has one;
+// integers are kept exact where they fit in an int64.
+func cellNumber(c *Cell) (cellNumberValue, bool) {
+	raw := c.raw
+	for {
+		if inner, ok := raw.(*Cell); ok {
+			raw = inner.raw
+		} else if inner, ok := raw.(Cell); ok {
+			raw = inner.raw
+		} else if f, ok := raw.(*Formula); ok {
+			raw = f.value
+		} else {
+			break
+		}
+	}
+	if raw == nil {
+		return cellNumberValue{}, false
+	}
+	if s, ok := raw.(string); ok {
+		s = strings.TrimSpace(s)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return cellNumberValue{i: i}, true
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return cellNumberValue{f: f, isFloat: true}, true
+		}
+		return cellNumberValue{}, false
+	}
+	v := reflect.ValueOf(raw)
+	switch {
+	case v.CanInt():
+		return cellNumberValue{i: v.Int()}, true
+	case v.CanUint():
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return cellNumberValue{i: int64(u)}, true
+		}
+		return cellNumberValue{f: float64(v.Uint()), isFloat: true}, true
+	case v.CanFloat():
+		return cellNumberValue{f: v.Float(), isFloat: true}, true
+	}
+	return cellNumberValue{}, false
+}
+
+// SetAggregator sets the Aggregator used for the column's cell in the totals
+// row of the table's footer; nil removes any aggregator.
+func (c *Column) SetAggregator(a Aggregator) {
+	t := c.ofTable
+	n := t.columnNumber(c)
+	if n < 1 {
+		t.AddError(ErrAggregateDefaultsColumn)
+		return
+	}
+	if a != nil && !c.aggregating {
+		// Registered just once; the callback finds the current aggregator
+		// when invoked.
+		if err := t.RegisterPropertyCallback(c, CB_AT_RENDER_POSTCELL, CB_ON_ITSELF, totalsCallback{}); err != nil {
+			t.AddError(err)
+			return
+		}
+		c.aggregating = true
+	}
+	c.aggregator = a
+	if a != nil {
+		t.sizeTotalsRow()
+	} else if t.totalsRow != nil && n <= len(t.totalsRow.cells) {
+		if n == 1 {
+			t.totalsRow.setCell(0, cellForItem(t.totalsLabel))
+		} else {
+			t.totalsRow.setCell(n-1, NewCell(nil))
+		}
+	}
+}
+
+// Aggregator returns the Aggregator set for the column, or nil.
+func (c *Column) Aggregator() Aggregator {
+	return c.aggregator
+}
+
+// columnNumber returns the number of a column of the table, counting from 1,
+// or 0 for the defaults column, or -1 if the column is not in the table.
+func (t *ATable) columnNumber(c *Column) int {
+	for i := range t.columns {
+		if &t.columns[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// totalsCallback is the render-time callback which computes a column's cell
+// in the totals row.
+type totalsCallback struct{}
+
+func (totalsCallback) UpdateProperties(owner PropertyOwner) error {
+	c, ok := owner.(*Column)
+	if !ok || c.aggregator == nil {
+		return nil
+	}
+	t := c.ofTable
+	n := t.columnNumber(c)
+	if n < 1 {
+		return nil
+	}
+	t.sizeTotalsRow()
+	t.totalsRow.setCell(n-1, NewCell(c.aggregator.Aggregate(t.bodyCellsOfColumn(n))))
+	return nil
+}
+
+// bodyCellsOfColumn returns the cells to be aggregated for a column,
+// numbered from 1.
+func (t *ATable) bodyCellsOfColumn(n int) []*Cell {
+	return cellsToAggregate(t.rows, n)
+}
+
+// cellsToAggregate returns the cells of the rows in a column, numbered from
+// 1, skipping separators, subtotals, omitted rows, short rows, and positions
+// covered by a cell spanning into them.
+func cellsToAggregate(rows []*Row, n int) []*Cell {
+	cells := make([]*Cell, 0, len(rows))
+	for _, row := range rows {
+		if row.isSeparator || row.isSubtotal || n > len(row.cells) {
+			continue
+		}
+		if omit, ok := row.GetProperty(properties.Omit).(bool); ok && omit {
+			continue
+		}
+		if row.cells[n-1].SpanCovered() {
+			continue
+		}
+		cells = append(cells, &row.cells[n-1])
+	}
+	return cells
+}
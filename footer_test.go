@@ -0,0 +1,94 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/properties"
+)
+
+func TestFooterRows(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("item", "qty")
+	tb.AddRowItems("pear", 2)
+	tb.AddRowItems("apple", 3)
+	tb.AddFooterItems("note", "none")
+	T.Equal(tb.Errors(), nil, "no errors adding footer rows")
+	T.Equal(tb.NRows(), 2, "footer rows are not body rows")
+	T.Equal(tb.TotalsRow(), nil, "no totals row without aggregators")
+
+	T.ExpectSuccess(tb.SortByColumnNumber(0, tabular.SORT_ASC), "sorted")
+	T.Equal(tb.AllRows()[0].Cells()[0].String(), "apple", "body sorted")
+	footer := tb.FooterRows()
+	T.Equal(len(footer), 1, "one footer row")
+	T.Equal(footer[0].Cells()[0].String(), "note", "footer not sorted into body")
+}
+
+func TestAggregators(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("item", "qty", "price", "note")
+	tb.AddRowItems("pear", 2, 1.5, "ripe")
+	tb.AddSeparator()
+	tb.AddRowItems("apple", 3, "2.25", nil)
+	tb.AddRowItems("fig", uint8(5), 4, "")
+	tb.SetTotalsLabel("Total")
+	tb.Column(2).SetAggregator(tabular.AGG_SUM)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.Column(4).SetAggregator(tabular.AGG_COUNT_NON_EMPTY)
+	T.Equal(tb.Errors(), nil, "no errors setting aggregators")
+
+	totals := tb.TotalsRow()
+	T.NotEqual(totals, nil, "aggregators create a totals row")
+	footer := tb.FooterRows()
+	T.Equal(len(footer), 1, "totals row is in the footer")
+	T.Equal(footer[0], totals, "footer holds the totals row")
+
+	tb.InvokeRenderCallbacks()
+	cells := totals.Cells()
+	T.Equal(cells[0].Item(), "Total", "label in first column")
+	T.Equal(cells[1].Item(), int64(10), "sum of integers is an integer")
+	T.Equal(cells[2].Item(), 7.75, "sum with floats is a float")
+	T.Equal(cells[3].Item(), 1, "count of non-empty cells")
+
+	// totals follow the body as rendered
+	tb.AddRowItems("kiwi", 10, 0.25, "new")
+	tb.AllRows()[0].SetProperty(properties.Omit, true)
+	tb.Column(2).SetAggregator(tabular.AGG_MEAN)
+	tb.Column(3).SetAggregator(tabular.AGG_MAX)
+	tb.Column(4).SetAggregator(tabular.AGG_COUNT)
+	tb.InvokeRenderCallbacks()
+	cells = tb.TotalsRow().Cells()
+	T.Equal(cells[1].Item(), 6.0, "mean skips omitted rows")
+	T.Equal(cells[2].Item(), 4, "max of mixed values")
+	T.Equal(cells[3].Item(), 3, "count of body rows")
+
+	tb.Column(3).SetAggregator(tabular.AGG_MIN)
+	tb.Column(4).SetAggregator(tabular.AggregatorFunc(func(cells []*tabular.Cell) any {
+		return cells[len(cells)-1].String()
+	}))
+	tb.InvokeRenderCallbacks()
+	cells = tb.TotalsRow().Cells()
+	T.Equal(cells[2].Item(), 0.25, "min of mixed values")
+	T.Equal(cells[3].Item(), "new", "custom aggregator")
+
+	tb.Column(2).SetAggregator(nil)
+	tb.Column(3).SetAggregator(nil)
+	tb.Column(4).SetAggregator(nil)
+	T.Equal(tb.TotalsRow(), nil, "totals row gone with the last aggregator")
+	T.Equal(tb.FooterRows(), nil, "no footer rows left")
+
+	tb.Column(0).SetAggregator(tabular.AGG_SUM)
+	T.Equal(tb.Errors(), []error{tabular.ErrAggregateDefaultsColumn}, "can't aggregate the defaults column")
+}
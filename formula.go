@@ -0,0 +1,369 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Formulas
+//
+// A cell can hold a *Formula, whose value is computed from other cells of the
+// body of the table: by a Go func, or an expression per ParseFormula.  The
+// cells a formula reads are recorded as it is evaluated, so that when a cell
+// is changed by Cell.SetItem only the formulas depending upon it, directly or
+// through other formulas, are evaluated again.  Formulas are evaluated when
+// the table is rendered, before any other render-time callbacks, or when
+// Recalculate is called.
+//
+// One Formula can be put in many cells, as when filling a column: the first
+// cell of the body which it is put in holds it, and each other gets a copy of
+// its own, evaluated for that cell, so Cell.Item finds the formula of a cell.
+//
+// Changes to the layout of the table, such as adding, moving or sorting rows
+// and editing columns, cause every formula to be evaluated afresh.  Changes
+// made to cells other than by SetItem or SetCellAt are not seen.
+
+// ErrFormulaCycle is the error held by a formula which depends upon itself,
+// directly or through other formulas.
+var ErrFormulaCycle = errors.New("formula depends upon itself")
+
+// ErrorFormula is recorded in the table for a formula whose evaluation failed.
+type ErrorFormula struct {
+	Location CellLocation
+	Err      error
+}
+
+func (e ErrorFormula) Error() string {
+	return fmt.Sprintf("tabular: formula at [row %d, col %d]: %v", e.Location.Row, e.Location.Column, e.Err)
+}
+
+func (e ErrorFormula) Unwrap() error { return e.Err }
+
+// A FormulaFunc computes the value of a formula, reading other cells through
+// the FormulaEnv.
+type FormulaFunc func(env *FormulaEnv) (any, error)
+
+// A Formula is an item for a cell whose value is computed.
+type Formula struct {
+	fn     FormulaFunc
+	source string // the expression, if parsed
+
+	placed    bool // held by a cell of the body of a table
+	row       *Row // the row holding the formula, once found
+	value     any
+	err       error
+	evaluated bool
+}
+
+// NewFormula returns a formula computed by a Go func.
+func NewFormula(fn FormulaFunc) *Formula {
+	return &Formula{fn: fn}
+}
+
+// place returns the formula to put in a cell of the body of a table: the
+// formula itself the first time, and a fresh copy after.
+func (f *Formula) place() *Formula {
+	if !f.placed {
+		f.placed = true
+		return f
+	}
+	return &Formula{fn: f.fn, source: f.source, placed: true}
+}
+
+// Value returns the value computed by the formula's last evaluation, and any
+// error from it.
+func (f *Formula) Value() (any, error) {
+	return f.value, f.err
+}
+
+// String returns the value of the formula as shown in its cell: "#ERROR" if
+// its evaluation failed, and nothing if it has not yet been evaluated.
+func (f *Formula) String() string {
+	switch {
+	case f.err != nil:
+		return "#ERROR"
+	case !f.evaluated || f.value == nil:
+		return ""
+	}
+	return cellForItem(f.value).String()
+}
+
+// MarshalJSON encodes the value of the formula, or null if it has not yet
+// been evaluated or its evaluation failed, so that the json renderer emits
+// the value rather than the formula.
+func (f *Formula) MarshalJSON() ([]byte, error) {
+	if f.err != nil || !f.evaluated {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.value)
+}
+
+// Source returns the expression from which the formula was parsed, or "" for
+// a formula computed by a Go func.
+func (f *Formula) Source() string {
+	return f.source
+}
+
+// A FormulaEnv is given to a FormulaFunc, for reading the cells it depends
+// upon.  Reading a cell holding a formula evaluates that formula first if
+// needed, and gives its value.
+type FormulaEnv struct {
+	graph *formulaGraph
+	f     *Formula
+	cell  *Cell
+	deps  []cellKey
+}
+
+// Location returns the location of the cell holding the formula.
+func (env *FormulaEnv) Location() CellLocation {
+	return env.cell.Location()
+}
+
+// Row returns the row holding the formula.
+func (env *FormulaEnv) Row() *Row {
+	return env.f.row
+}
+
+// At returns the item in the cell of the table at the given location, or the
+// value of the formula held there.
+func (env *FormulaEnv) At(loc CellLocation) (any, error) {
+	c, err := env.graph.t.CellAt(loc)
+	if err != nil {
+		return nil, err
+	}
+	return env.read(c)
+}
+
+// Named returns the item in the cell of the formula's own row in the named
+// column, or the value of the formula held there.
+func (env *FormulaEnv) Named(column string) (any, error) {
+	c, err := env.f.row.CellNamed(column)
+	if err != nil {
+		return nil, err
+	}
+	return env.read(c)
+}
+
+// Number returns an item read by At or Named as a float64, as the totals row
+// would treat it; an empty item is 0.
+func (env *FormulaEnv) Number(item any) (float64, error) {
+	if item == nil {
+		return 0, nil
+	}
+	c := NewCell(item)
+	if c.Empty() {
+		return 0, nil
+	}
+	n, ok := cellNumber(&c)
+	if !ok {
+		return 0, fmt.Errorf("not a number: %q", c.String())
+	}
+	return n.float(), nil
+}
+
+func (env *FormulaEnv) read(c *Cell) (any, error) {
+	env.deps = append(env.deps, cellKey{row: c.inRow, column: c.columnNum})
+	raw := c.innermost().raw
+	if g, ok := raw.(*Formula); ok {
+		if err := env.graph.evaluate(g); err != nil {
+			return nil, err
+		}
+		return g.value, g.err
+	}
+	return raw, nil
+}
+
+// cellKey identifies a cell of the body by its row and current column.
+type cellKey struct {
+	row    *Row
+	column int
+}
+
+type formulaState int
+
+const (
+	formulaDirty formulaState = iota
+	formulaEvaluating
+	formulaClean
+)
+
+// A formulaGraph tracks the formulas of a table and the cells each depends
+// upon.
+type formulaGraph struct {
+	t          *ATable
+	state      map[*Formula]formulaState
+	at         map[cellKey]*Formula
+	deps       map[*Formula][]cellKey
+	dependents map[cellKey]map[*Formula]bool
+
+	layout int // the table's layout when the formulas were last found
+}
+
+// enableFormulas starts tracking formulas for the table, registering the
+// render-time callback which evaluates them.
+func (t *ATable) enableFormulas() {
+	if t.formulas != nil {
+		return
+	}
+	t.formulas = &formulaGraph{t: t}
+	if err := t.RegisterPropertyCallback(t, CB_AT_RENDER_PRECELL, CB_ON_ITSELF, formulaCallback{}); err != nil {
+		t.AddError(err)
+	}
+}
+
+// formulaCallback is the render-time callback evaluating formulas.
+type formulaCallback struct{}
+
+func (formulaCallback) UpdateProperties(owner PropertyOwner) error {
+	if t, ok := owner.(*ATable); ok {
+		t.Recalculate()
+	}
+	return nil
+}
+
+// Recalculate evaluates every formula in the body of the table which needs
+// it: those never evaluated, and those depending upon cells changed since.
+// It is called when the table is rendered, so need only be called to read
+// the values of formulas at other times.  Errors from formulas accumulate in
+// the table, as ErrorFormula.
+func (t *ATable) Recalculate() {
+	if t.formulas == nil {
+		return
+	}
+	g := t.formulas
+	if g.layoutChanged() {
+		g.rescan()
+	}
+	for _, row := range t.rows {
+		for i := range row.cells {
+			if f, ok := row.cells[i].raw.(*Formula); ok && g.state[f] == formulaDirty {
+				_ = g.evaluate(f)
+			}
+		}
+	}
+}
+
+// layoutChanged reports whether the rows or the columns of the table have
+// been rearranged since the formulas were last found.
+func (g *formulaGraph) layoutChanged() bool {
+	return g.state == nil || g.layout != g.t.layout
+}
+
+// rescan finds every formula of the body afresh, all needing evaluation.
+func (g *formulaGraph) rescan() {
+	g.state = make(map[*Formula]formulaState)
+	g.at = make(map[cellKey]*Formula)
+	g.deps = make(map[*Formula][]cellKey)
+	g.dependents = make(map[cellKey]map[*Formula]bool)
+	g.layout = g.t.layout
+	for _, row := range g.t.rows {
+		for i := range row.cells {
+			if f, ok := row.cells[i].raw.(*Formula); ok {
+				f.row = row
+				g.state[f] = formulaDirty
+				g.at[cellKey{row: row, column: i + 1}] = f
+			}
+		}
+	}
+}
+
+// evaluate evaluates a formula if it is dirty, first evaluating any formulas
+// it reads.  The error returned is ErrFormulaCycle if the formula is already
+// being evaluated; any other failure is held by the formula.
+func (g *formulaGraph) evaluate(f *Formula) error {
+	switch g.state[f] {
+	case formulaClean:
+		return nil
+	case formulaEvaluating:
+		return ErrFormulaCycle
+	}
+	c := g.cellOf(f)
+	if c == nil {
+		// no longer in the table
+		delete(g.state, f)
+		return nil
+	}
+	g.state[f] = formulaEvaluating
+	env := &FormulaEnv{graph: g, f: f, cell: c}
+	value, err := f.fn(env)
+	for _, k := range g.deps[f] {
+		delete(g.dependents[k], f)
+	}
+	g.deps[f] = env.deps
+	for _, k := range env.deps {
+		if g.dependents[k] == nil {
+			g.dependents[k] = make(map[*Formula]bool)
+		}
+		g.dependents[k][f] = true
+	}
+	f.value, f.err, f.evaluated = value, err, true
+	if err != nil {
+		f.value = nil
+		g.t.AddError(ErrorFormula{Location: c.Location(), Err: err})
+	}
+	c.Update()
+	g.state[f] = formulaClean
+	return nil
+}
+
+// cellOf returns the cell holding a formula.
+func (g *formulaGraph) cellOf(f *Formula) *Cell {
+	if f.row == nil {
+		return nil
+	}
+	for i := range f.row.cells {
+		if raw, ok := f.row.cells[i].raw.(*Formula); ok && raw == f {
+			return &f.row.cells[i]
+		}
+	}
+	return nil
+}
+
+// changed is told of a cell of the body whose item has been replaced, and
+// marks the formulas depending upon it as needing evaluation.
+func (g *formulaGraph) changed(c *Cell) {
+	if g.state == nil {
+		return
+	}
+	k := cellKey{row: c.inRow, column: c.columnNum}
+	if old, ok := g.at[k]; ok {
+		delete(g.at, k)
+		delete(g.state, old)
+	}
+	if f, ok := c.raw.(*Formula); ok {
+		f.row = c.inRow
+		g.at[k] = f
+		g.state[f] = formulaDirty
+	}
+	g.markDependents(k)
+}
+
+// markDependents marks as dirty every formula depending upon the cell,
+// directly or through other formulas.
+func (g *formulaGraph) markDependents(k cellKey) {
+	for f := range g.dependents[k] {
+		if g.state[f] == formulaDirty {
+			continue
+		}
+		g.state[f] = formulaDirty
+		if c := g.cellOf(f); c != nil {
+			g.markDependents(cellKey{row: c.inRow, column: c.columnNum})
+		}
+	}
+}
+
+// formulaChanged is told of a cell whose item has been replaced.
+func (t *ATable) formulaChanged(c *Cell) {
+	if f, ok := c.raw.(*Formula); ok {
+		c.raw = f.place()
+		t.enableFormulas()
+	}
+	if t.formulas != nil {
+		t.formulas.changed(c)
+	}
+}
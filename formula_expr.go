@@ -0,0 +1,240 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero is the error held by a formula expression which divides
+// by zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
+// ErrorFormulaSyntax is returned by ParseFormula for an expression which it
+// cannot parse.
+type ErrorFormulaSyntax struct {
+	Expr    string
+	Offset  int
+	Problem string
+}
+
+func (e ErrorFormulaSyntax) Error() string {
+	return fmt.Sprintf("tabular: formula %q at offset %d: %s", e.Expr, e.Offset, e.Problem)
+}
+
+// ParseFormula returns a formula computed by an arithmetic expression, whose
+// value is a float64.  The expression holds numbers, the operators + - * /,
+// unary minus and parentheses, with the usual precedence, and references to
+// cells: {Name} is the cell in the column called Name in the formula's own
+// row, and [R,C] is the cell at row R, column C of the body, counting from 1.
+// A referenced cell which is empty counts as 0; one which is not a number is
+// an error.
+//
+//	ParseFormula("{Price} * {Quantity}")
+//	ParseFormula("([1,2] + [2,2]) / 2")
+func ParseFormula(expr string) (*Formula, error) {
+	p := &formulaParser{expr: expr}
+	node, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.expr) {
+		return nil, p.fail("unexpected %q", p.expr[p.pos])
+	}
+	return &Formula{
+		fn: func(env *FormulaEnv) (any, error) {
+			return node(env)
+		},
+		source: expr,
+	}, nil
+}
+
+// MustParseFormula is as ParseFormula, but panics if the expression cannot
+// be parsed; it is for expressions fixed in the source.
+func MustParseFormula(expr string) *Formula {
+	f, err := ParseFormula(expr)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// formulaNode evaluates one part of a parsed expression.
+type formulaNode func(env *FormulaEnv) (float64, error)
+
+type formulaParser struct {
+	expr string
+	pos  int
+}
+
+func (p *formulaParser) fail(format string, args ...any) error {
+	return ErrorFormulaSyntax{Expr: p.expr, Offset: p.pos, Problem: fmt.Sprintf(format, args...)}
+}
+
+func (p *formulaParser) skipSpace() {
+	for p.pos < len(p.expr) && (p.expr[p.pos] == ' ' || p.expr[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// peek returns the next byte which is not space, or 0 at the end.
+func (p *formulaParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.expr) {
+		return 0
+	}
+	return p.expr[p.pos]
+}
+
+// parseSum parses terms joined by + and -.
+func (p *formulaParser) parseSum() (formulaNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode(op, left, right)
+	}
+}
+
+// parseProduct parses factors joined by * and /.
+func (p *formulaParser) parseProduct() (formulaNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode(op, left, right)
+	}
+}
+
+// parseFactor parses a number, a reference to a cell, a parenthesized
+// expression, or a negated factor.
+func (p *formulaParser) parseFactor() (formulaNode, error) {
+	switch c := p.peek(); {
+	case c == 0:
+		return nil, p.fail("unexpected end")
+	case c == '-':
+		p.pos++
+		inner, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return func(env *FormulaEnv) (float64, error) {
+			n, err := inner(env)
+			return -n, err
+		}, nil
+	case c == '(':
+		p.pos++
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.fail("missing )")
+		}
+		p.pos++
+		return inner, nil
+	case c == '{':
+		end := strings.IndexByte(p.expr[p.pos:], '}')
+		if end < 0 {
+			return nil, p.fail("missing }")
+		}
+		name := p.expr[p.pos+1 : p.pos+end]
+		p.pos += end + 1
+		return func(env *FormulaEnv) (float64, error) {
+			item, err := env.Named(name)
+			if err != nil {
+				return 0, err
+			}
+			return env.Number(item)
+		}, nil
+	case c == '[':
+		end := strings.IndexByte(p.expr[p.pos:], ']')
+		if end < 0 {
+			return nil, p.fail("missing ]")
+		}
+		var loc CellLocation
+		parts := strings.Split(p.expr[p.pos+1:p.pos+end], ",")
+		if len(parts) == 2 {
+			r, rerr := strconv.Atoi(strings.TrimSpace(parts[0]))
+			c, cerr := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if rerr == nil && cerr == nil && r > 0 && c > 0 {
+				loc = CellLocation{Row: r, Column: c}
+			}
+		}
+		if loc.Row == 0 {
+			return nil, p.fail("want [row,column]")
+		}
+		p.pos += end + 1
+		return func(env *FormulaEnv) (float64, error) {
+			item, err := env.At(loc)
+			if err != nil {
+				return 0, err
+			}
+			return env.Number(item)
+		}, nil
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.expr) && (p.expr[p.pos] == '.' || (p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9')) {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.expr[start:p.pos], 64)
+		if err != nil {
+			p.pos = start
+			return nil, p.fail("bad number")
+		}
+		return func(*FormulaEnv) (float64, error) { return n, nil }, nil
+	default:
+		return nil, p.fail("unexpected %q", c)
+	}
+}
+
+func binaryNode(op byte, left, right formulaNode) formulaNode {
+	return func(env *FormulaEnv) (float64, error) {
+		a, err := left(env)
+		if err != nil {
+			return 0, err
+		}
+		b, err := right(env)
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case '+':
+			return a + b, nil
+		case '-':
+			return a - b, nil
+		case '*':
+			return a * b, nil
+		}
+		if b == 0 {
+			return 0, ErrDivisionByZero
+		}
+		return a / b, nil
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+)
+
+func TestFormulas(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	evaluations := 0
+	discounted := func(env *tabular.FormulaEnv) (any, error) {
+		evaluations++
+		cost, err := env.Named("Cost")
+		if err != nil {
+			return nil, err
+		}
+		n, err := env.Number(cost)
+		return n * 0.5, err
+	}
+
+	tb := csv.New()
+	tb.AddHeaders("Item", "Price", "Quantity", "Cost", "Half")
+	tb.AddRowItems("bolt", 2, 10, tabular.MustParseFormula("{Price} * {Quantity}"), tabular.NewFormula(discounted))
+	tb.AddRowItems("nut", 1, 25, tabular.MustParseFormula("{Price} * {Quantity}"), tabular.NewFormula(discounted))
+	tb.AddRowItems("washer", 3, nil, tabular.MustParseFormula("{Price} * {Quantity}"), tabular.NewFormula(discounted))
+	tb.AddRowItems("all", nil, nil, tabular.MustParseFormula("[1,4] + [2,4] + [3,4]"), nil)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "render with formulas")
+	T.Equal(rendered, `"Item","Price","Quantity","Cost","Half"
+"bolt","2","10","20","10"
+"nut","1","25","25","12.5"
+"washer","3","","0","0"
+"all","","","45",""
+"Total","","35","",""
+`, "formulas evaluated before rendering")
+	T.Equal(evaluations, 3, "each Go formula evaluated once")
+
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 2, Column: 2}, 4), "change a price")
+	tb.Recalculate()
+	T.Equal(evaluations, 4, "only the formula depending on the changed price evaluated")
+	T.Equal(columnStrings(tb, 4), []string{"20", "100", "0", "120"}, "dependents recomputed")
+	T.Equal(columnStrings(tb, 5), []string{"10", "50", "0", ""}, "dependents of dependents recomputed")
+	tb.Recalculate()
+	T.Equal(evaluations, 4, "nothing evaluated without changes")
+
+	v, err := tb.AllRows()[3].Cells()[3].Item().(*tabular.Formula).Value()
+	T.ExpectSuccess(err, "formula value")
+	T.Equal(v, 120.0, "formula value is a float64")
+	T.Equal(tb.AllRows()[0].Cells()[3].Item().(*tabular.Formula).Source(), "{Price} * {Quantity}", "formula source")
+
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 1, Column: 3}, tabular.MustParseFormula("{Price} * 10")), "set a formula")
+	tb.Recalculate()
+	T.Equal(columnStrings(tb, 3), []string{"20", "25", "", ""}, "new formula evaluated")
+	T.Equal(columnStrings(tb, 4), []string{"40", "100", "0", "140"}, "dependents of new formula recomputed")
+
+	sorted := tb.Clone(false)
+	T.ExpectSuccess(sorted.SortBy(tabular.SortKey{Name: "Cost"}), "sort by computed column")
+	T.ExpectSuccess(sorted.SetCellAt(tabular.CellLocation{Row: 1, Column: 3}, 2), "change the clone")
+	sorted.Recalculate()
+	T.Equal(columnStrings(sorted, 1), []string{"washer", "bolt", "nut", "all"}, "sorted by computed values")
+	T.Equal(columnStrings(sorted, 4), []string{"6", "40", "100", "146"}, "references re-read after sorting")
+	T.Equal(columnStrings(tb, 4), []string{"40", "100", "0", "140"}, "original unchanged by its clone")
+	T.Equal(tb.Errors(), nil, "no errors from formulas")
+}
+
+func TestFormulaInManyCells(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	cost := tabular.MustParseFormula("{P} * {Q}")
+	tb := csv.New()
+	tb.AddHeaders("P", "Q", "Cost")
+	tb.AddRowItems(2, 3, cost)
+	tb.AddRowItems(5, 7, cost)
+	row := tabular.NewRow().Add(tabular.NewCell(1)).Add(tabular.NewCell(1)).Add(tabular.NewCell(nil))
+	tb.AddRow(row)
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 3, Column: 3}, cost), "set the same formula in another cell")
+
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "render one formula put in many cells")
+	T.Equal(rendered, `"P","Q","Cost"
+"2","3","6"
+"5","7","35"
+"1","1","1"
+`, "each cell evaluates the formula for its own row")
+	T.Equal(tb.AllRows()[0].Cells()[2].Item(), any(cost), "first cell holds the formula itself")
+	T.Equal(tb.AllRows()[1].Cells()[2].Item() != any(cost), true, "other cells hold copies")
+
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 2, Column: 1}, 10), "change an input of a copy")
+	tb.Recalculate()
+	T.Equal(columnStrings(tb, 3), []string{"6", "70", "1"}, "only that copy recomputed")
+	T.Equal(tb.Errors(), nil, "no errors from shared formula")
+}
+
+func TestFormulaHeaderlessColumnEdits(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddRowItems(2, 5, tabular.MustParseFormula("[1,1] * 10"))
+	tb.Recalculate()
+	T.Equal(columnStrings(tb, 3), []string{"20"}, "formula evaluated")
+
+	T.ExpectSuccess(tb.MoveColumn(1, 2), "move a column without headers")
+	tb.Recalculate()
+	T.Equal(columnStrings(tb, 3), []string{"50"}, "formula evaluated again after moving a column")
+
+	T.ExpectSuccess(tb.InsertColumn(1, nil, 7), "insert a column without headers")
+	tb.Recalculate()
+	T.Equal(columnStrings(tb, 4), []string{"70"}, "formula evaluated again after inserting a column")
+	T.Equal(tb.Errors(), nil, "no errors from formulas")
+}
+
+func TestFormulaErrors(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("A", "B", "C")
+	tb.AddRowItems(tabular.MustParseFormula("{B} + 1"), tabular.MustParseFormula("{A} * 2"), tabular.MustParseFormula("1 / {D}"))
+	tb.AddRowItems(1, "many", tabular.MustParseFormula("{B} / ({A} - 1)"))
+	tb.AddRowItems(2, 0, tabular.MustParseFormula("{A} / {B}"))
+	tb.Recalculate()
+
+	errs := tb.Errors()
+	T.Equal(len(errs), 5, "an error for each failed formula")
+	for i, want := range []error{tabular.ErrFormulaCycle, tabular.ErrFormulaCycle, tabular.ErrorNoSuchColumn("D")} {
+		T.Equal(errors.Is(errs[i], want), true, fmt.Sprintf("formula error %d: %v", i, errs[i]))
+	}
+	T.Equal(errs[0].(tabular.ErrorFormula).Location, tabular.CellLocation{Row: 1, Column: 2}, "cycle found at the second formula")
+	T.Equal(errs[3].Error(), `tabular: formula at [row 2, col 3]: not a number: "many"`, "not a number")
+	T.Equal(errors.Is(errs[4], tabular.ErrDivisionByZero), true, "division by zero")
+	T.Equal(columnStrings(tb, 1), []string{"#ERROR", "1", "2"}, "errors shown in cells")
+
+	_, err := tabular.ParseFormula("{A} * (2 + ")
+	T.Equal(err, tabular.ErrorFormulaSyntax{Expr: "{A} * (2 + ", Offset: 11, Problem: "unexpected end"}, "syntax error")
+	_, err = tabular.ParseFormula("[1] + 1")
+	T.Equal(err, tabular.ErrorFormulaSyntax{Expr: "[1] + 1", Offset: 0, Problem: "want [row,column]"}, "bad reference")
+}
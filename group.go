@@ -0,0 +1,102 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+)
+
+// ErrNoAggregator is returned by GroupBy for a GroupTotal without an
+// Aggregator.
+var ErrNoAggregator = errors.New("group total has no aggregator")
+
+// A GroupTotal asks GroupBy to total one column for each group.  The column
+// is found by Name if that is set, else by Column, counting from 1.
+type GroupTotal struct {
+	Column     int
+	Name       string
+	Aggregator Aggregator
+}
+
+// GroupBy arranges the body of the table into groups of rows which are equal
+// on the key column: the rows are sorted by the key, as SortBy does, with a
+// separator between each group and the next.  Any separators and subtotal
+// rows already in the body are first removed, so that GroupBy can be called
+// again, with another key.
+//
+// If totals are given, then each group is followed by a subtotal row, which
+// holds the group's key and, in each totalled column, the result of the
+// aggregator over the group's cells, as they are when GroupBy is called.  The
+// aggregators are also set on their columns, per Column.SetAggregator, so
+// that the totals row holds the grand totals; subtotal rows are never
+// aggregated themselves.
+func (t *ATable) GroupBy(key SortKey, totals ...GroupTotal) error {
+	keys, err := t.sortColumns([]SortKey{key})
+	if err != nil {
+		return err
+	}
+	totalled := make([]int, len(totals))
+	for i := range totals {
+		if totalled[i], err = t.sortKeyColumn(SortKey{Column: totals[i].Column, Name: totals[i].Name}); err != nil {
+			return err
+		}
+		if totals[i].Aggregator == nil {
+			return ErrNoAggregator
+		}
+	}
+
+	rows := make([]*Row, 0, len(t.rows))
+	for _, row := range t.rows {
+		if !row.isSeparator && !row.isSubtotal {
+			rows = append(rows, row)
+		}
+	}
+	rs := newRowSorter(rows, keys)
+	rs.sort()
+
+	grouped := make([]*Row, 0, len(rows)+len(rows)/2)
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for end < len(rows) && keys[0].compare(&rs.entries[start], &rs.entries[end], 0) == 0 {
+			end++
+		}
+		if start > 0 {
+			grouped = append(grouped, newSeparator())
+		}
+		grouped = append(grouped, rows[start:end]...)
+		if len(totals) > 0 {
+			grouped = append(grouped, t.subtotalRow(rows[start:end], keys[0].index, totalled, totals))
+		}
+		start = end
+	}
+	for _, row := range grouped {
+		if row.isSeparator || row.isSubtotal {
+			row.inTable = t
+			row.ErrorContainer = t.ErrorContainer
+		}
+	}
+	t.rows = grouped
+	for i := range totals {
+		t.columns[totalled[i]].SetAggregator(totals[i].Aggregator)
+	}
+	t.recomputeSpans()
+	return nil
+}
+
+// subtotalRow makes the subtotal row for a group of rows, given the 0-based
+// index of the key column and the columns to total, counting from 1.
+func (t *ATable) subtotalRow(group []*Row, keyIndex int, totalled []int, totals []GroupTotal) *Row {
+	row := t.NewRowSizedFor()
+	row.isSubtotal = true
+	for i := 0; i < t.nColumns; i++ {
+		row.addCell(NewCell(nil))
+	}
+	row.setCell(keyIndex, NewCell(group[0].sortCell(keyIndex).raw))
+	for i := range totals {
+		n := totalled[i]
+		row.setCell(n-1, NewCell(totals[i].Aggregator.Aggregate(cellsToAggregate(group, n))))
+	}
+	return row
+}
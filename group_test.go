@@ -0,0 +1,78 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/texttable"
+)
+
+func TestGroupBy(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := texttable.New()
+	_, err := tb.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	tb.AddHeaders("Region", "Host", "Cost")
+	tb.AddRowItems("us-east", "web1", 30)
+	tb.AddRowItems("eu-west", "db1", 120)
+	tb.AddSeparator()
+	tb.AddRowItems("us-east", "db2", 120)
+	tb.AddRowItems("eu-west", "web3", 30)
+	tb.AddRowItems("ap-south", "web4", 10)
+	tb.SetTotalsLabel("Total")
+
+	T.ExpectSuccess(tb.GroupBy(tabular.SortKey{Name: "Region"}), "group without totals")
+	T.Equal(firstColumn(tb), []string{"ap-south", "--", "eu-west", "eu-west", "--", "us-east", "us-east"}, "grouped, old separators replaced")
+	T.Equal([]string{tb.AllRows()[2].Cells()[1].String(), tb.AllRows()[3].Cells()[1].String()}, []string{"db1", "web3"}, "stable within groups")
+	T.Equal(tb.TotalsRow(), (*tabular.Row)(nil), "no totals without aggregators")
+	checkLocations(T, tb)
+
+	err = tb.GroupBy(tabular.SortKey{Name: "Region", Order: tabular.SORT_DESC},
+		tabular.GroupTotal{Name: "Cost", Aggregator: tabular.AGG_SUM},
+		tabular.GroupTotal{Column: 2, Aggregator: tabular.AGG_COUNT})
+	T.ExpectSuccess(err, "group with subtotals")
+	T.Equal(tb.AllRows()[2].IsSubtotal(), true, "subtotal row follows group")
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "grouped table rendered")
+	T.Equal(rendered, strings.TrimLeft(`
++----------+------+------+
+| Region   | Host | Cost |
++----------+------+------+
+| us-east  | web1 | 30   |
+| us-east  | db2  | 120  |
+| us-east  | 2    | 150  |
++----------+------+------+
+| eu-west  | db1  | 120  |
+| eu-west  | web3 | 30   |
+| eu-west  | 2    | 150  |
++----------+------+------+
+| ap-south | web4 | 10   |
+| ap-south | 1    | 10   |
++----------+------+------+
+| Total    | 5    | 310  |
++----------+------+------+
+`, "\n"), "grouped table rendered with subtotals and grand total")
+
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Cost"}), "sort after grouping")
+	T.Equal(columnStrings(tb.Filter(func(r *tabular.Row) bool { return r.Cells()[0].String() == "eu-west" }), 3), []string{"30", "120", "150"}, "subtotal stays at the end of its group")
+	T.ExpectSuccess(tb.SortGroupsBy(tabular.SortKey{Name: "Region"}), "sort groups after grouping")
+	T.Equal(firstColumn(tb), []string{"ap-south", "ap-south", "--", "eu-west", "eu-west", "eu-west", "--", "us-east", "us-east", "us-east"}, "subtotals move with their groups")
+	T.Equal(tb.AllRows()[1].IsSubtotal(), true, "subtotal moved with group")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.GroupBy(tabular.SortKey{Name: "Host"}), "regroup")
+	T.Equal(tb.NRows(), 9, "subtotal rows removed on regrouping")
+
+	T.Equal(tb.GroupBy(tabular.SortKey{Name: "Zone"}), tabular.ErrorNoSuchColumn("Zone"), "group by missing column")
+	T.Equal(tb.GroupBy(tabular.SortKey{Column: 1}, tabular.GroupTotal{Column: 3}), tabular.ErrNoAggregator, "total without aggregator")
+	T.Equal(tb.Errors(), nil, "no errors grouping")
+}
@@ -0,0 +1,95 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"strings"
+)
+
+// DEFAULT_HEADER_GROUP_SEPARATOR is used by renderers which flatten header
+// groups into the column headers, between the names joined together.
+const DEFAULT_HEADER_GROUP_SEPARATOR = " "
+
+// A HeaderGroup names a run of adjacent columns, to be shown as a
+// super-header above the column headers, eg "Latency" over "p50", "p95" and
+// "p99".  A nil Name leaves the columns without a group.  Columns values
+// below 1 are treated as 1.
+type HeaderGroup struct {
+	Name    any
+	Columns int
+}
+
+// AddHeaderGroups adds a row of header groups, covering the columns from the
+// left, and returns the table.  Each call adds a row beneath any previous
+// group rows, so add the outermost groups first; the column headers set with
+// AddHeaders are always the bottom header row and still provide the column
+// names.  A group row covering fewer columns than the table leaves the
+// remaining columns without a group.
+//
+// Header groups are only shown when a table also has column headers.
+func (t *ATable) AddHeaderGroups(groups ...HeaderGroup) Table {
+	gr := NewRowWithCapacity(len(groups))
+	gr.ErrorContainer = t.ErrorContainer
+	for _, g := range groups {
+		if g.Name == nil {
+			for range max(g.Columns, 1) {
+				gr.Add(NewCell(nil))
+			}
+			continue
+		}
+		cell := cellForItem(g.Name)
+		cell.SetSpan(g.Columns, 1)
+		gr.Add(cell)
+	}
+	t.resizeColumnsAtLeast(len(gr.cells))
+	markColumnSpans(gr)
+	t.headerGroupRows = append(t.headerGroupRows, gr)
+
+	t.invokeOuterRowAddCallbacks(gr)
+	return t
+}
+
+// HeaderGroupRows returns the rows of header groups, top-most first, or nil
+// if there are none.  The cells of each row are as for a row containing
+// cells spanning columns.
+func (t *ATable) HeaderGroupRows() []*Row {
+	if t.headerGroupRows == nil {
+		return nil
+	}
+	rr := make([]*Row, len(t.headerGroupRows))
+	copy(rr, t.headerGroupRows)
+	return rr
+}
+
+// FlattenHeaders returns the column headers of a table, with the names of
+// any header groups above each column joined in front of the column's own
+// header, using the given separator; eg, "Latency p50".  This is for
+// renderers which can only show one row of headers.  If there are no header
+// groups, the table's headers are returned unchanged.
+func FlattenHeaders(t Table, separator string) []Cell {
+	headers := t.Headers()
+	groupRows := t.HeaderGroupRows()
+	if headers == nil || groupRows == nil {
+		return headers
+	}
+	flat := make([]Cell, len(headers))
+	for i := range headers {
+		names := make([]string, 0, len(groupRows)+1)
+		for _, gr := range groupRows {
+			cells := gr.Cells()
+			if i >= len(cells) {
+				continue
+			}
+			if s := cells[i].SpanAnchor().String(); s != "" {
+				names = append(names, s)
+			}
+		}
+		if s := headers[i].SpanAnchor().String(); s != "" {
+			names = append(names, s)
+		}
+		flat[i] = NewCell(strings.Join(names, separator))
+	}
+	return flat
+}
@@ -0,0 +1,45 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+)
+
+func TestHeaderGroups(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaderGroups(tabular.HeaderGroup{Name: "Service", Columns: 4})
+	tb.AddHeaderGroups(tabular.HeaderGroup{Columns: 1}, tabular.HeaderGroup{Name: "Latency", Columns: 3})
+	tb.AddHeaders("host", "p50", "p95", "p99")
+	tb.AddRowItems("alpha", 3, 9, 40)
+	T.Equal(tb.Errors(), nil, "no errors adding header groups")
+	T.Equal(tb.NColumns(), 4, "groups don't create extra columns")
+
+	groups := tb.HeaderGroupRows()
+	T.Equal(len(groups), 2, "two rows of header groups")
+	T.Equal(len(groups[0].Cells()), 4, "group row has a cell per column")
+	T.Equal(groups[1].Cells()[2].SpanAnchor().String(), "Latency", "group covers columns")
+
+	col, err := tb.ColumnNamed("p95")
+	T.ExpectSuccess(err, "leaf headers still name columns")
+	T.Equal(col, tb.Column(3), "leaf header names the right column")
+	_, err = tb.ColumnNamed("Latency")
+	T.ExpectError(err, "group names are not column names")
+
+	flat := tabular.FlattenHeaders(tb, "/")
+	have := make([]string, len(flat))
+	for i := range flat {
+		have[i] = flat[i].String()
+	}
+	T.Equal(have, []string{"Service/host", "Service/Latency/p50", "Service/Latency/p95", "Service/Latency/p99"},
+		"flattened headers join group names")
+}
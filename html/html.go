@@ -1,4 +1,4 @@
-// Copyright © 2016,2025 Pennock Tech, LLC.
+// Copyright © 2016,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -8,6 +8,10 @@ Like everything else, the interface is still very much in flux.
 Where texttable used properties on the table to control rendering, HTMLTable
 uses a wrapper object which methods can be set upon.
 I want to see which approach is "better".
+
+Cells spanning several columns or rows are emitted with colspan and rowspan
+attributes, counting only the columns and rows which are not omitted.
+Footer rows, including any totals row, are emitted in a tfoot element.
 */
 package html // import "go.pennock.tech/tabular/html"
 
@@ -24,7 +28,8 @@ import (
 
 // HTMLTable wraps a tabular Table to provide some extra information used
 // in rendering to HTML.  Id and Class are properties of the top-level table.
-// Caption will be inserted if present.
+// Caption will be inserted if present, else the table's title is used; any
+// subtitle of the table is put within the caption too.
 // TemplateName can be used if you are managing general html/template namespaces,
 // else the template will be unnamed.
 type HTMLTable struct {
@@ -40,6 +45,7 @@ type HTMLTable struct {
 	template *template.Template
 
 	cachedOmitColumns []bool
+	cachedOmitRows    []bool
 }
 
 // Wrap returns an HTMLTable rendering object for the given tabular Table.
@@ -57,8 +63,9 @@ func New() *HTMLTable {
 // classes for each row.  The HTMLTable object is returned, to permit chaining.
 //
 // The callable is passed the row number (starting from 0 for the header, 1 for
-// the first body row) and whatever object is passed as the context here, which
-// may be used for persisting state.
+// the first body row; any rows of header groups are also 0) and whatever
+// object is passed as the context here, which may be used for persisting
+// state.
 //
 // Note that use of a context here means that an HTMLTable can not be concurrent
 // rendered from two threads (unless you're doing something very strange and
@@ -95,33 +102,152 @@ func (ht *HTMLTable) Render() (string, error) {
 
 const rawTableTemplateStr = `{{/**/ -}}
 <table {{- with .Class}} class="{{.}}"{{end}} {{- with .Id}} id="{{.}}"{{end}} {{- with (BGColor Table) }} style="background-color: {{.}}"{{end}}>
-{{- with .Caption}}
-  <caption>{{.}}</caption>
+{{- if or .Caption .Subtitle}}
+  <caption>{{.Caption}}{{with .Subtitle}}{{if $.Caption}}<br>{{end}}<small>{{.}}</small>{{end}}</caption>
 {{- end}}
   <colgroup>
-{{- range $n, $hdr := Headers}}<col class="{{ColumnClass $hdr}}" {{- with (BGColor (Column $n)) }} style="background-color: {{.}}"{{end}} />{{end -}}
+{{- range ColGroup}}<col class="{{ColumnClass .Header}}" {{- with (BGColor .Column) }} style="background-color: {{.}}"{{end}} />{{end -}}
   </colgroup>
   <thead>
+{{- range HeaderGroups}}
+    <tr {{- if $.HaveRowClass}} class="{{RowClass 0}}"{{end}}>
+{{- range .}}<th {{- with .ColSpan}} colspan="{{.}}"{{end}}>{{.Cell}}</th>{{end -}}
+    </tr>
+{{- end}}
     <tr {{- if .HaveRowClass}} class="{{RowClass 0}}"{{end}}>
-{{- range Headers}}<th>{{.}}</th>{{end -}}
+{{- range Headers}}<th {{- with .ColSpan}} colspan="{{.}}"{{end}}>{{.Cell}}</th>{{end -}}
     </tr>
   </thead>
   <tbody>
 {{- range $i, $row := Rows}}{{if OmitRow $row | not}}{{if $row.IsSeparator | not}}
     <tr {{- if $.HaveRowClass}} class="{{RowClass (OnePlus $i)}}"{{end}} {{- with (BGColor .) }} style="background-color: {{.}}"{{end}}>
-{{- range $cell := CellsOf $row }}<td {{- with (BGColor $cell) }} style="background-color: {{.}}"{{end}}>{{$cell}}</td>{{end -}}
+{{- range CellsOf $row }}<td {{- with .ColSpan}} colspan="{{.}}"{{end}} {{- with .RowSpan}} rowspan="{{.}}"{{end}} {{- with (BGColor .Cell) }} style="background-color: {{.}}"{{end}}>{{.Cell}}</td>{{end -}}
     </tr>
 {{- end}}{{end}}{{end}}
   </tbody>
+{{- with FooterRows}}
+  <tfoot>
+{{- range .}}
+    <tr {{- with (BGColor .) }} style="background-color: {{.}}"{{end}}>
+{{- range CellsOf . }}<td {{- with .ColSpan}} colspan="{{.}}"{{end}} {{- with (BGColor .Cell) }} style="background-color: {{.}}"{{end}}>{{.Cell}}</td>{{end -}}
+    </tr>
+{{- end}}
+  </tfoot>
+{{- end}}
 </table>
 `
 
-func cellsNotOmitted(cells []tabular.Cell, omit []bool) []*tabular.Cell {
-	r := make([]*tabular.Cell, 0, len(cells))
+// htmlField is one th or td element: a cell, with its colspan and rowspan
+// attribute values, which are zero when not needed.
+type htmlField struct {
+	Cell    *tabular.Cell
+	ColSpan int
+	RowSpan int
+}
+
+// htmlColumn is one col element within the colgroup.
+type htmlColumn struct {
+	Header *tabular.Cell
+	Column *tabular.Column
+}
+
+// fieldsNotOmitted returns the fields to emit for a row of cells, skipping
+// those in omitted columns and those covered by a spanning cell.
+func (ht *HTMLTable) fieldsNotOmitted(cells []tabular.Cell) []htmlField {
+	omit := ht.cachedOmitColumns
+	r := make([]htmlField, 0, len(cells))
 	for i := range cells {
-		if !omit[i] {
-			r = append(r, &cells[i])
+		if omit[i] {
+			continue
+		}
+		anchor := cells[i].SpanAnchor()
+		columns, rows := anchor.Span()
+		if anchor == &cells[i] && columns == 1 && rows == 1 {
+			r = append(r, htmlField{Cell: anchor})
+			continue
+		}
+		if anchor.Row() != cells[i].Row() {
+			if !ht.rowOmitted(anchor.Row()) {
+				// emitted in an earlier row, with a rowspan
+				continue
+			}
+			// degenerate: the spanning cell's own row is omitted
+			r = append(r, htmlField{Cell: &cells[i]})
+			continue
+		}
+		first := anchor.Location().Column - 1
+		shownFirst := -1
+		colSpan := 0
+		for c := first; c < first+columns && c < len(omit); c++ {
+			if omit[c] {
+				continue
+			}
+			if shownFirst < 0 {
+				shownFirst = c
+			}
+			colSpan++
+		}
+		if shownFirst != i {
+			continue
+		}
+		rowSpan := 0
+		if rows > 1 {
+			rowNum := anchor.Location().Row
+			for n := rowNum; n < rowNum+rows && n <= len(ht.cachedOmitRows); n++ {
+				if !ht.cachedOmitRows[n-1] {
+					rowSpan++
+				}
+			}
+		}
+		if colSpan == 1 {
+			colSpan = 0
+		}
+		if rowSpan == 1 {
+			rowSpan = 0
+		}
+		r = append(r, htmlField{Cell: anchor, ColSpan: colSpan, RowSpan: rowSpan})
+	}
+	return r
+}
+
+// rowOmitted is true if the given row is a body row which is not being shown.
+func (ht *HTMLTable) rowOmitted(r *tabular.Row) bool {
+	n := r.Location().Row
+	if n < 1 || n > len(ht.cachedOmitRows) {
+		return false
+	}
+	return ht.cachedOmitRows[n-1]
+}
+
+// headerGroups returns the fields for each row of header groups, with short
+// rows padded out to the full width of the table.
+func (ht *HTMLTable) headerGroups() [][]htmlField {
+	groupRows := ht.Table.HeaderGroupRows()
+	if groupRows == nil || ht.Table.Headers() == nil {
+		return nil
+	}
+	r := make([][]htmlField, len(groupRows))
+	for i, gr := range groupRows {
+		cells := gr.Cells()
+		if len(cells) < len(ht.cachedOmitColumns) {
+			padded := make([]tabular.Cell, len(ht.cachedOmitColumns))
+			copy(padded, cells)
+			cells = padded
 		}
+		r[i] = ht.fieldsNotOmitted(cells)
+	}
+	return r
+}
+
+// colGroup returns the columns which are not omitted, with their headers.
+func (ht *HTMLTable) colGroup() []htmlColumn {
+	headers := ht.Table.Headers()
+	r := make([]htmlColumn, 0, len(headers))
+	for i := range headers {
+		if ht.cachedOmitColumns[i] {
+			continue
+		}
+		r = append(r, htmlColumn{Header: headers[i].SpanAnchor(), Column: ht.Table.Column(i + 1)})
 	}
 	return r
 }
@@ -187,14 +313,16 @@ func lookupColor(item any, prop any) string {
 
 func (ht *HTMLTable) getFuncs() template.FuncMap {
 	return template.FuncMap{
-		"Table":       func() tabular.Table { return ht.Table },
-		"Headers":     func() []*tabular.Cell { return cellsNotOmitted(ht.Table.Headers(), ht.cachedOmitColumns) },
-		"RowClass":    func(i int) template.HTMLAttr { return ht.rowClassGenerator(i, ht.rowClassCtx) },
-		"Column":      func(i int) *tabular.Column { return ht.Table.Column(i + 1) },
-		"ColumnClass": cellToColumnClass,
-		"CellsOf":     func(r *tabular.Row) []*tabular.Cell { return cellsNotOmitted(r.Cells(), ht.cachedOmitColumns) },
-		"OnePlus":     func(i int) int { return i + 1 },
-		"Rows":        func() []*tabular.Row { return ht.Table.AllRows() },
+		"Table":        func() tabular.Table { return ht.Table },
+		"Headers":      func() []htmlField { return ht.fieldsNotOmitted(ht.Table.Headers()) },
+		"ColGroup":     ht.colGroup,
+		"HeaderGroups": ht.headerGroups,
+		"RowClass":     func(i int) template.HTMLAttr { return ht.rowClassGenerator(i, ht.rowClassCtx) },
+		"ColumnClass":  cellToColumnClass,
+		"CellsOf":      func(r *tabular.Row) []htmlField { return ht.fieldsNotOmitted(r.Cells()) },
+		"OnePlus":      func(i int) int { return i + 1 },
+		"Rows":         func() []*tabular.Row { return ht.Table.AllRows() },
+		"FooterRows":   func() []*tabular.Row { return ht.Table.FooterRows() },
 		"OmitRow": func(r *tabular.Row) (bool, error) {
 			return properties.ExpectBoolPropertyOrNil(properties.Omit, r.GetProperty(properties.Omit), "html:OmitRow", "row", 0)
 		},
@@ -213,6 +341,7 @@ func (ht *HTMLTable) RenderTo(w io.Writer) (err error) {
 	}
 	defer func() {
 		ht.cachedOmitColumns = nil
+		ht.cachedOmitRows = nil
 	}()
 
 	if ht.template == nil {
@@ -225,13 +354,18 @@ func (ht *HTMLTable) RenderTo(w io.Writer) (err error) {
 		ht.template.Funcs(ht.getFuncs())
 	}
 
+	caption := ht.Caption
+	if caption == "" {
+		caption = ht.Title()
+	}
 	renderData := struct {
-		Id, Class, Caption string
-		HaveRowClass       bool
+		Id, Class, Caption, Subtitle string
+		HaveRowClass                 bool
 	}{
 		Id:           ht.Id,
 		Class:        ht.Class,
-		Caption:      ht.Caption,
+		Caption:      caption,
+		Subtitle:     ht.Subtitle(),
 		HaveRowClass: ht.rowClassGenerator != nil,
 	}
 
@@ -264,5 +398,15 @@ func (ht *HTMLTable) populateOmitCache() error {
 		}
 	}
 
+	rows := ht.AllRows()
+	ht.cachedOmitRows = make([]bool, len(rows))
+	for i, r := range rows {
+		if ht.cachedOmitRows[i], err = properties.ExpectBoolPropertyOrNil(
+			properties.Omit, r.GetProperty(properties.Omit),
+			"html:RenderTo", "row", i+1); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -234,3 +234,150 @@ func TestHTMLTableColorSupport(t *testing.T) {
 	T.Equal(rendered, should, "colored table rendered to HTML correctly")
 
 }
+
+func TestHTMLTableSpans(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	ht := html.New()
+	T.NotEqual(ht, nil, "have a table")
+
+	ht.AddHeaders(tabular.NewSpanningCell("latency", 2, 1), "unit")
+	ht.AddRowItems(tabular.NewSpanningCell("p50", 1, 3), 3, "ms")
+	ht.AddRowItems(4, "ms")
+	ht.AddRowItems(5, "ms")
+	ht.AddRowItems(tabular.NewSpanningCell("not measured", 2, 1), "-")
+	T.Equal(ht.Errors(), nil, "no errors just adding items")
+
+	should := `<table>
+  <colgroup><col class="col-latency" /><col class="col-latency" /><col class="col-unit" /></colgroup>
+  <thead>
+    <tr><th colspan="2">latency</th><th>unit</th></tr>
+  </thead>
+  <tbody>
+    <tr><td rowspan="3">p50</td><td>3</td><td>ms</td></tr>
+    <tr><td>4</td><td>ms</td></tr>
+    <tr><td>5</td><td>ms</td></tr>
+    <tr><td colspan="2">not measured</td><td>-</td></tr>
+  </tbody>
+</table>
+`
+	rendered, err := ht.Render()
+	T.ExpectSuccess(err, "spanning table rendered to HTML")
+	T.Equal(ht.Errors(), nil, "no errors accumulated in table through rendering")
+	T.Equal(rendered, should, "spanning table rendered to HTML correctly")
+
+	ht.AllRows()[1].SetProperty(properties.Omit, true)
+	ht.Column(1).SetProperty(properties.Omit, true)
+	should = `<table>
+  <colgroup><col class="col-latency" /><col class="col-unit" /></colgroup>
+  <thead>
+    <tr><th>latency</th><th>unit</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>3</td><td>ms</td></tr>
+    <tr><td>5</td><td>ms</td></tr>
+    <tr><td>not measured</td><td>-</td></tr>
+  </tbody>
+</table>
+`
+	rendered, err = ht.Render()
+	T.ExpectSuccess(err, "spanning table with omissions rendered to HTML")
+	T.Equal(rendered, should, "spans count only what is not omitted")
+}
+
+func TestHTMLTableHeaderGroups(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	ht := html.New()
+	ht.AddHeaderGroups(tabular.HeaderGroup{Columns: 1}, tabular.HeaderGroup{Name: "Latency", Columns: 2})
+	ht.AddHeaders("host", "p50", "p99", "unit")
+	ht.AddRowItems("alpha", 3, 40, "ms")
+	T.Equal(ht.Errors(), nil, "no errors just adding items")
+
+	const should = `<table>
+  <colgroup><col class="col-host" /><col class="col-p50" /><col class="col-p99" /><col class="col-unit" /></colgroup>
+  <thead>
+    <tr><th></th><th colspan="2">Latency</th><th></th></tr>
+    <tr><th>host</th><th>p50</th><th>p99</th><th>unit</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>alpha</td><td>3</td><td>40</td><td>ms</td></tr>
+  </tbody>
+</table>
+`
+	rendered, err := ht.Render()
+	T.ExpectSuccess(err, "grouped table rendered to HTML")
+	T.Equal(ht.Errors(), nil, "no errors accumulated in table through rendering")
+	T.Equal(rendered, should, "grouped table rendered to HTML correctly")
+}
+
+func TestHTMLTableTitles(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	ht := html.New()
+	ht.AddHeaders("host")
+	ht.AddRowItems("alpha")
+	ht.SetTitle("Hosts")
+
+	const tail = `
+  <colgroup><col class="col-host" /></colgroup>
+  <thead>
+    <tr><th>host</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>alpha</td></tr>
+  </tbody>
+</table>
+`
+	rendered, err := ht.Render()
+	T.ExpectSuccess(err, "titled table rendered to HTML")
+	T.Equal(rendered, "<table>\n  <caption>Hosts</caption>"+tail, "title used as caption")
+
+	ht.SetSubtitle("as of today")
+	rendered, err = ht.Render()
+	T.ExpectSuccess(err, "titled table rendered to HTML with subtitle")
+	T.Equal(rendered, "<table>\n  <caption>Hosts<br><small>as of today</small></caption>"+tail, "subtitle within caption")
+
+	ht.Caption = "Overridden"
+	ht.SetSubtitle("")
+	rendered, err = ht.Render()
+	T.ExpectSuccess(err, "titled table rendered to HTML with explicit caption")
+	T.Equal(rendered, "<table>\n  <caption>Overridden</caption>"+tail, "explicit caption wins over title")
+}
+
+func TestHTMLTableFooter(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	ht := html.New()
+	ht.AddHeaders("item", "qty")
+	ht.AddRowItems("apple", 3)
+	ht.AddRowItems("pear", 2)
+	ht.AddFooterItems(tabular.NewSpanningCell("checked", 2, 1))
+	ht.SetTotalsLabel("Total")
+	ht.Column(2).SetAggregator(tabular.AGG_SUM)
+	T.Equal(ht.Errors(), nil, "no errors just adding items")
+
+	const should = `<table>
+  <colgroup><col class="col-item" /><col class="col-qty" /></colgroup>
+  <thead>
+    <tr><th>item</th><th>qty</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>apple</td><td>3</td></tr>
+    <tr><td>pear</td><td>2</td></tr>
+  </tbody>
+  <tfoot>
+    <tr><td colspan="2">checked</td></tr>
+    <tr><td>Total</td><td>5</td></tr>
+  </tfoot>
+</table>
+`
+	rendered, err := ht.Render()
+	T.ExpectSuccess(err, "table with footer rendered to HTML")
+	T.Equal(ht.Errors(), nil, "no errors accumulated in table through rendering")
+	T.Equal(rendered, should, "table with footer rendered to HTML correctly")
+}
@@ -0,0 +1,245 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownJoinKind is returned by Join for a JoinKind it does not know.
+var ErrUnknownJoinKind = errors.New("unknown join kind")
+
+// ErrNoJoinKeys is returned by Join if given no keys to join on.
+var ErrNoJoinKeys = errors.New("no join keys given")
+
+// JoinKind says which rows Join keeps.  The zero value is JOIN_INNER.
+type JoinKind int
+
+const (
+	JOIN_INNER JoinKind = iota // only rows matched in both tables
+	JOIN_LEFT                  // every row of the left table
+	JOIN_FULL                  // every row of both tables
+)
+
+func (jk JoinKind) String() string {
+	switch jk {
+	case JOIN_INNER:
+		return "inner"
+	case JOIN_LEFT:
+		return "left"
+	case JOIN_FULL:
+		return "full"
+	default:
+		return "JoinKind(" + strconv.Itoa(int(jk)) + ")"
+	}
+}
+
+// A JoinKey names a column of each table to be matched by Join.  If Right is
+// not set, then the column has the same name in both tables.
+type JoinKey struct {
+	Left  string
+	Right string
+}
+
+// On returns the JoinKeys for columns with the same names in both tables.
+func On(names ...string) []JoinKey {
+	keys := make([]JoinKey, len(names))
+	for i := range names {
+		keys[i] = JoinKey{Left: names[i]}
+	}
+	return keys
+}
+
+// Join returns a new table combining the rows of two tables, or views, which
+// match on the key columns: the cells in each pair of key columns have the
+// same String form.  As in SQL, a row with an empty key cell matches nothing.
+// Separators, subtotal rows and rows marked with properties.Omit are skipped.
+//
+// The columns of the new table are those of the left table, followed by those
+// of the right table other than its key columns; the key columns of the left
+// table hold the keys of right rows which matched no left row.  A column of the
+// right table whose name is already taken is given the name with " (2)"
+// appended, or " (3)", and so on, until the name is unique; columns without
+// headers are left unnamed.  Columns and cells carry copies of their
+// properties across, so that changing them in the new table leaves the tables
+// joined alone.
+//
+// The rows are in the order of the left table, with each followed by the rows
+// of the right table which it matched, in their order; a left row matching
+// several right rows is repeated for each.  With JOIN_LEFT and JOIN_FULL, a
+// left row matching nothing is kept, with empty cells for the right table, and
+// with JOIN_FULL, the right rows matching nothing follow, in their order.
+func Join(left, right Table, kind JoinKind, keys ...JoinKey) (Table, error) {
+	switch kind {
+	case JOIN_INNER, JOIN_LEFT, JOIN_FULL:
+	default:
+		return nil, ErrUnknownJoinKind
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoJoinKeys
+	}
+	leftKeys := make([]int, len(keys))
+	rightKeys := make([]int, len(keys))
+	isRightKey := make(map[int]bool, len(keys))
+	for i := range keys {
+		rightName := keys[i].Right
+		if rightName == "" {
+			rightName = keys[i].Left
+		}
+		var err error
+		if leftKeys[i], err = columnNumberNamed(left, keys[i].Left); err != nil {
+			return nil, err
+		}
+		if rightKeys[i], err = columnNumberNamed(right, rightName); err != nil {
+			return nil, err
+		}
+		isRightKey[rightKeys[i]] = true
+	}
+
+	var rightColumns []int
+	for n := 1; n <= right.NColumns(); n++ {
+		if !isRightKey[n] {
+			rightColumns = append(rightColumns, n)
+		}
+	}
+	nLeft := left.NColumns()
+
+	nt := New()
+	nt.AddHeaders(joinHeaders(left, right, rightColumns)...)
+	for n := 1; n <= nLeft; n++ {
+		if from, to := left.Column(n), nt.Column(n); from != nil && to != nil {
+			to.propertyImpl = from.propertyImpl.clone()
+		}
+	}
+	for j, n := range rightColumns {
+		if from, to := right.Column(n), nt.Column(nLeft+1+j); from != nil && to != nil {
+			to.propertyImpl = from.propertyImpl.clone()
+		}
+	}
+
+	rightRows := dataRows(right)
+	byKey := make(map[string][]int)
+	for i, row := range rightRows {
+		if k, ok := joinKeyOf(row, rightKeys); ok {
+			byKey[k] = append(byKey[k], i)
+		}
+	}
+	matched := make([]bool, len(rightRows))
+
+	addRow := func(l, r *Row) {
+		cells := make([]Cell, 0, nLeft+len(rightColumns))
+		for n := 1; n <= nLeft; n++ {
+			if l != nil {
+				cells = append(cells, plainCell(l, n))
+			} else {
+				cells = append(cells, NewCell(nil))
+			}
+		}
+		if l == nil {
+			for i := range keys {
+				cells[leftKeys[i]-1] = plainCell(r, rightKeys[i])
+			}
+		}
+		for _, n := range rightColumns {
+			if r != nil {
+				cells = append(cells, plainCell(r, n))
+			} else {
+				cells = append(cells, NewCell(nil))
+			}
+		}
+		row := NewRowWithCapacity(len(cells))
+		for i := range cells {
+			row.Add(cells[i])
+		}
+		nt.AddRow(row)
+	}
+
+	for _, l := range dataRows(left) {
+		var matches []int
+		if k, ok := joinKeyOf(l, leftKeys); ok {
+			matches = byKey[k]
+		}
+		for _, i := range matches {
+			matched[i] = true
+			addRow(l, rightRows[i])
+		}
+		if len(matches) == 0 && kind != JOIN_INNER {
+			addRow(l, nil)
+		}
+	}
+	if kind == JOIN_FULL {
+		for i, r := range rightRows {
+			if !matched[i] {
+				addRow(nil, r)
+			}
+		}
+	}
+	return nt, nil
+}
+
+// joinHeaders returns the headers of a join, one for every column of the
+// left table, even those beyond its headers, then the right table's,
+// disambiguating those of the right table where they clash.
+func joinHeaders(left, right Table, rightColumns []int) []any {
+	headers := make([]any, 0, left.NColumns()+len(rightColumns))
+	taken := make(map[string]bool)
+	leftHeaders := left.Headers()
+	for n := 1; n <= left.NColumns(); n++ {
+		name := ""
+		if n <= len(leftHeaders) {
+			name = leftHeaders[n-1].String()
+		}
+		if name != "" {
+			taken[name] = true
+		}
+		headers = append(headers, name)
+	}
+	rightHeaders := right.Headers()
+	for _, n := range rightColumns {
+		name := ""
+		if n <= len(rightHeaders) {
+			name = rightHeaders[n-1].String()
+		}
+		unique := name
+		for i := 2; name != "" && taken[unique]; i++ {
+			unique = name + " (" + strconv.Itoa(i) + ")"
+		}
+		taken[unique] = true
+		headers = append(headers, unique)
+	}
+	return headers
+}
+
+// joinKeyOf returns the key of a row for a join, given its key columns, or
+// false if any key cell is empty.
+func joinKeyOf(row *Row, columns []int) (string, bool) {
+	parts := make([]string, len(columns))
+	for i, n := range columns {
+		c := row.sortCell(n - 1)
+		if c.Empty() || c.SpanCovered() {
+			return "", false
+		}
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, "\x00"), true
+}
+
+// plainCell returns a copy of the cell in a column of a row, counting from 1,
+// without any span and with its own copy of the properties and callbacks, for
+// adding to another row; a cell covered by a span is empty.
+func plainCell(row *Row, n int) Cell {
+	if n > len(row.cells) || row.cells[n-1].SpanCovered() {
+		return NewCell(nil)
+	}
+	c := row.cells[n-1]
+	c.inRow = nil
+	c.columnNum = 0
+	c.span = cellSpan{}
+	c.propertyImpl = c.propertyImpl.clone()
+	c.callbacks = c.callbacks.clone()
+	return c
+}
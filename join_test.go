@@ -0,0 +1,122 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+	"go.pennock.tech/tabular/properties/align"
+)
+
+func TestJoin(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	inventory := tabular.New()
+	inventory.AddHeaders("Host", "Site", "Owner")
+	inventory.AddRowItems("web1", "lon", "ops")
+	inventory.AddRowItems("web2", "nyc", "ops")
+	inventory.AddSeparator()
+	inventory.AddRowItems("db1", "lon", "dba")
+	inventory.AddRowItems(nil, "lon", "nobody")
+
+	metrics := tabular.New()
+	metrics.AddHeaders("Name", "CPU", "Owner")
+	metrics.AddRowItems("db1", 80, "dba")
+	metrics.AddRowItems("web1", 12, "web")
+	metrics.AddRowItems("mail1", 3, "ops")
+	metrics.AddRowItems("web1", 15, "web")
+	metrics.Column(2).SetProperty(align.PropertyType, align.Right)
+
+	on := tabular.JoinKey{Left: "Host", Right: "Name"}
+	_, err := tabular.Join(inventory, metrics, tabular.JOIN_INNER, tabular.On("Host")...)
+	T.Equal(err, tabular.ErrorNoSuchColumn("Host"), "key must be in both tables")
+	_, err = tabular.Join(inventory, metrics, tabular.JOIN_INNER)
+	T.Equal(err, tabular.ErrNoJoinKeys, "keys needed")
+	_, err = tabular.Join(inventory, metrics, tabular.JoinKind(9), on)
+	T.Equal(err, tabular.ErrUnknownJoinKind, "unknown kind rejected")
+
+	checks := []struct {
+		kind tabular.JoinKind
+		want string
+	}{
+		{tabular.JOIN_INNER, `"Host","Site","Owner","CPU","Owner (2)"
+"web1","lon","ops","12","web"
+"web1","lon","ops","15","web"
+"db1","lon","dba","80","dba"
+`},
+		{tabular.JOIN_LEFT, `"Host","Site","Owner","CPU","Owner (2)"
+"web1","lon","ops","12","web"
+"web1","lon","ops","15","web"
+"web2","nyc","ops","",""
+"db1","lon","dba","80","dba"
+"","lon","nobody","",""
+`},
+		{tabular.JOIN_FULL, `"Host","Site","Owner","CPU","Owner (2)"
+"web1","lon","ops","12","web"
+"web1","lon","ops","15","web"
+"web2","nyc","ops","",""
+"db1","lon","dba","80","dba"
+"","lon","nobody","",""
+"mail1","","","3","ops"
+`},
+	}
+	for _, check := range checks {
+		joined, err := tabular.Join(inventory, metrics, check.kind, on)
+		T.ExpectSuccess(err, check.kind.String()+" join")
+		rendered, err := csv.Wrap(joined).Render()
+		T.ExpectSuccess(err, check.kind.String()+" join rendered")
+		T.Equal(rendered, check.want, check.kind.String()+" join")
+	}
+
+	joined, err := tabular.Join(inventory, metrics, tabular.JOIN_LEFT, on, tabular.JoinKey{Left: "Owner"})
+	T.ExpectSuccess(err, "join on two keys")
+	T.Equal(headerNames(joined), []string{"Host", "Site", "Owner", "CPU"}, "right key columns dropped")
+	T.Equal(columnStrings(joined, 4), []string{"", "", "80", ""}, "rows matched on both keys")
+	T.Equal(joined.Column(4).GetProperty(align.PropertyType), align.Right, "column properties carried")
+
+	ops, err := inventory.Project("Owner", "Host")
+	T.ExpectSuccess(err, "project inventory")
+	joined, err = tabular.Join(metrics, ops, tabular.JOIN_INNER, tabular.On("Owner")...)
+	T.ExpectSuccess(err, "join a view")
+	T.Equal(columnStrings(joined, 4), []string{"db1", "web1", "web2"}, "view joined")
+	T.Equal(columnStrings(joined, 1), []string{"db1", "mail1", "mail1"}, "left row repeated per match")
+	T.Equal(inventory.Errors(), nil, "no errors joining")
+}
+
+func TestJoinWideAndIndependent(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	left := tabular.New()
+	left.AddHeaders("id")
+	left.AddRowItems(1, "extra")
+	right := tabular.New()
+	right.AddHeaders("id", "v")
+	right.AddRowItems(1, "one")
+
+	joined, err := tabular.Join(left, right, tabular.JOIN_INNER, tabular.On("id")...)
+	T.ExpectSuccess(err, "join left table wider than its headers")
+	T.Equal(headerNames(joined), []string{"id", "", "v"}, "every left column kept, before the right columns")
+	T.Equal(columnStrings(joined, 2), []string{"extra"}, "unnamed left column")
+	T.Equal(columnStrings(joined, 3), []string{"one"}, "right column after all the left columns")
+
+	// Properties set later sit above those changed in the join.
+	left.Column(1).SetProperty(align.PropertyType, align.Right)
+	left.Column(1).SetProperty("marker", "id column")
+	left.AllRows()[0].Cells()[1].SetProperty(align.PropertyType, align.Center)
+	left.AllRows()[0].Cells()[1].SetProperty("marker", "extra cell")
+	joined, err = tabular.Join(left, right, tabular.JOIN_INNER, tabular.On("id")...)
+	T.ExpectSuccess(err, "join again")
+	T.Equal(joined.Column(1).GetProperty(align.PropertyType), align.Right, "column properties carried")
+	joined.Column(1).SetProperty(align.PropertyType, align.Left)
+	joined.AllRows()[0].Cells()[1].SetProperty(align.PropertyType, align.Left)
+	T.Equal(left.Column(1).GetProperty(align.PropertyType), align.Right, "left column properties unchanged")
+	T.Equal(left.AllRows()[0].Cells()[1].GetProperty(align.PropertyType), align.Center, "left cell properties unchanged")
+}
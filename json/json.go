@@ -1,4 +1,4 @@
-// Copyright © 2018,2025 Pennock Tech, LLC.
+// Copyright © 2018,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -15,8 +15,19 @@ import (
 )
 
 // A JSONTable wraps a tabular.Table to act as a render control for JSON output.
+//
+// JSON has no notion of merged cells, so a value spanning several columns or
+// rows is repeated for each of the keys in each of the objects which it
+// covers.  Each column needs its own header, to provide a key, so a header
+// cell spanning columns will cause rendering to fail.
+//
+// Footer rows are not emitted, except that the totals of aggregated columns
+// are included when using an envelope; see SetEnvelope.  Nor are subtotal
+// rows, as made by GroupBy, so that every object is one row of data.
 type JSONTable struct {
 	tabular.Table
+
+	envelope bool
 }
 
 // Wrap returns a JSONTable rendering object for the given tabular.Table.
@@ -26,6 +37,18 @@ func Wrap(t tabular.Table) *JSONTable {
 	}
 }
 
+// SetEnvelope controls whether the array of rows is emitted by itself, which
+// is the default, or inside an envelope object which can carry details about
+// the table: the array is the value of the "rows" key, and the table's title
+// and subtitle, if set, are the values of the "title" and "subtitle" keys.
+// If any column has an aggregator, then the "totals" key follows the rows,
+// with an object holding the total of each aggregated column.
+// The JSONTable is returned, to permit chaining.
+func (jt *JSONTable) SetEnvelope(onoff bool) *JSONTable {
+	jt.envelope = onoff
+	return jt
+}
+
 // New returns a JSONTable with a new Table inside it, access via .Table
 // or just use the interface methods on the JSONTable.
 func New() *JSONTable {
@@ -124,6 +147,11 @@ func (jt *JSONTable) RenderTo(w io.Writer) error {
 		}
 	}
 
+	if jt.envelope {
+		if err = jt.emitEnvelopeStart(w); err != nil {
+			return err
+		}
+	}
 	if _, err = io.WriteString(w, "[\n"); err != nil {
 		return err
 	}
@@ -133,7 +161,7 @@ func (jt *JSONTable) RenderTo(w io.Writer) error {
 		if skipRow, err = properties.ExpectBoolPropertyOrNil(properties.Omit, r.GetProperty(properties.Omit), "text:renderTo", "row", rowNum+1); err != nil {
 			return err
 		}
-		if skipRow {
+		if skipRow || r.IsSubtotal() {
 			continue
 		}
 		if needComma {
@@ -156,10 +184,53 @@ func (jt *JSONTable) RenderTo(w io.Writer) error {
 	// We assume need newline prefix because no comma+newline from new row,
 	// but if the table is empty, this will result in "[\n\n]\n" which is
 	// slightly ugly.  But valid.  So live with it.
-	if _, err = io.WriteString(w, "\n]\n"); err != nil {
+	if !jt.envelope {
+		_, err = io.WriteString(w, "\n]\n")
 		return err
 	}
-	return nil
+	if _, err = io.WriteString(w, "\n]"); err != nil {
+		return err
+	}
+	if totals := jt.TotalsRow(); totals != nil {
+		if _, err = io.WriteString(w, `, "totals": `); err != nil {
+			return err
+		}
+		// Only the aggregated columns have totals; a label is not a total.
+		notTotals := make([]bool, columnCount)
+		for i := range columnCount {
+			notTotals[i] = omitColumns[i] || jt.Column(i+1).Aggregator() == nil
+		}
+		if err = jt.emitRowAsJSONObject(w, skipableColumns, notTotals, keys, totals.Cells()); err != nil {
+			return err
+		}
+	}
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
+
+// emitEnvelopeStart writes the start of the envelope object, up to the key
+// for the rows.
+func (jt *JSONTable) emitEnvelopeStart(w io.Writer) error {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for _, field := range []struct{ key, value string }{
+		{"title", jt.Title()},
+		{"subtitle", jt.Subtitle()},
+	} {
+		if field.value == "" {
+			continue
+		}
+		t, err := json.Marshal(field.value)
+		if err != nil {
+			return fmt.Errorf("json:RenderTo: %s JSON encoding failure: %s", field.key, err)
+		}
+		b.WriteString(`"` + field.key + `": `)
+		b.Write(t)
+		b.WriteString(", ")
+	}
+	b.WriteString(`"rows": `)
+	_, err := w.Write(b.Bytes())
+	return err
 }
 
 // emitRowAsJSONObject handles just one row, as a JSON object, it does not handle
@@ -180,7 +251,9 @@ func (jt *JSONTable) emitRowAsJSONObject(w io.Writer, skipableColumns []bool, om
 		if omitColumns[i] {
 			continue
 		}
-		if skipableColumns[i] && cells[i].Empty() {
+		// A cell covered by another spanning over it repeats that cell's value.
+		cell := cells[i].SpanAnchor()
+		if skipableColumns[i] && cell.Empty() {
 			continue
 		}
 		if _, err = io.WriteString(w, separator); err != nil {
@@ -198,8 +271,13 @@ func (jt *JSONTable) emitRowAsJSONObject(w io.Writer, skipableColumns []bool, om
 		// to set a String() method, but json.Marshal doesn't use that as a
 		// marshalling method.  If we rework our API, then we can suggest that
 		// cell data types have MarshalText() method.
-		fallback := cells[i].String()
-		t, err := json.Marshal(cells[i].Item())
+		fallback := cell.String()
+		item := cell.Item()
+		if cell.Format() != "" {
+			// a formatted item is emitted as shown, as other renderers do
+			item = fallback
+		}
+		t, err := json.Marshal(item)
 		if err != nil {
 			return fmt.Errorf("json:RenderTo: column %d header JSON encoding failure: %s", i+1, err)
 		}
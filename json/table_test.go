@@ -254,3 +254,146 @@ func TestSkipable(t *testing.T) {
 	T.ExpectSuccess(err, "skipable-column table renders without errors second-skipable, default-removed")
 	T.Equal(have, shouldSkipableSecond, "got correct skipable-column output second-skipable, default-removed")
 }
+
+func TestSpansJSON(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tab_json.New()
+	tb.AddHeaders("name", "min", "max")
+	tb.AddRowItems(tabular.NewSpanningCell("latency", 1, 2), 3, 9)
+	tb.AddRowItems(4, 12)
+	tb.AddRowItems("errors", tabular.NewSpanningCell(nil, 2, 1))
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := `[
+{"name": "latency", "min": 3, "max": 9},
+{"name": "latency", "min": 4, "max": 12},
+{"name": "errors", "min": null, "max": null}
+]
+`
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "spanning table renders without errors")
+	T.Equal(have, should, "spanned values repeated for every key covered")
+
+	tb.AddHeaders(tabular.NewSpanningCell("name", 2, 1), "max")
+	have, err = tb.Render()
+	T.ExpectErrorf(err, "spanning header should have failed to render, instead got: %v", have)
+}
+
+func TestEnvelopeJSON(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tab_json.New()
+	tb.AddHeaders("host", "up")
+	tb.AddRowItems("alpha", true)
+	tb.SetEnvelope(true)
+
+	should := `{"rows": [
+{"host": "alpha", "up": true}
+]}
+`
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "untitled envelope renders without errors")
+	T.Equal(have, should, "envelope without title")
+
+	tb.SetTitle("Hosts")
+	tb.SetSubtitle(`"quoted"`)
+	should = `{"title": "Hosts", "subtitle": "\"quoted\"", "rows": [
+{"host": "alpha", "up": true}
+]}
+`
+	have, err = tb.Render()
+	T.ExpectSuccess(err, "titled envelope renders without errors")
+	T.Equal(have, should, "envelope with title and subtitle")
+
+	have, err = tb.SetEnvelope(false).Render()
+	T.ExpectSuccess(err, "titled table without envelope renders without errors")
+	T.Equal(have, "[\n{\"host\": \"alpha\", \"up\": true}\n]\n", "no envelope, no title")
+}
+
+func TestTotalsJSON(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tab_json.New()
+	tb.AddHeaders("host", "load", "jobs")
+	tb.AddRowItems("alpha", 1.5, 3)
+	tb.AddRowItems("beta", 0.5, 4)
+	tb.SetTotalsLabel("all")
+	tb.Column(2).SetAggregator(tabular.AGG_MAX)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "table with totals renders without errors")
+	T.Equal(have, "[\n{\"host\": \"alpha\", \"load\": 1.5, \"jobs\": 3},\n{\"host\": \"beta\", \"load\": 0.5, \"jobs\": 4}\n]\n",
+		"no totals without envelope")
+
+	should := `{"rows": [
+{"host": "alpha", "load": 1.5, "jobs": 3},
+{"host": "beta", "load": 0.5, "jobs": 4}
+], "totals": {"load": 1.5, "jobs": 7}}
+`
+	have, err = tb.SetEnvelope(true).Render()
+	T.ExpectSuccess(err, "envelope with totals renders without errors")
+	T.Equal(have, should, "totals of aggregated columns in envelope")
+}
+
+func TestSubtotalsJSON(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tab_json.New()
+	tb.AddHeaders("region", "qty")
+	tb.AddRowItems("east", 3)
+	tb.AddRowItems("west", 2)
+	tb.AddRowItems("east", 4)
+	T.ExpectSuccess(tb.GroupBy(tabular.SortKey{Name: "region"}, tabular.GroupTotal{Name: "qty", Aggregator: tabular.AGG_SUM}), "group with subtotals")
+
+	have, err := tb.SetEnvelope(true).Render()
+	T.ExpectSuccess(err, "grouped table renders without errors")
+	T.Equal(have, `{"rows": [
+{"region": "east", "qty": 3},
+{"region": "east", "qty": 4},
+
+{"region": "west", "qty": 2}
+], "totals": {"qty": 9}}
+`, "subtotal rows not emitted as objects")
+}
+
+func TestFormattedJSON(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tab_json.New()
+	tb.SetSchema(tabular.Schema{{Name: "host"}, {Name: "load", Format: "%.2f"}})
+	tb.AddRowItems("web1", 0.5)
+
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "formatted table renders without errors")
+	T.Equal(have, `[
+{"host": "web1", "load": "0.50"}
+]
+`, "formatted items emitted as shown")
+	T.Equal(tb.AllRows()[0].Cells()[1].Item(), any(0.5), "formatted cell holds its item")
+}
+
+func TestFormulasJSON(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tab_json.New()
+	tb.AddHeaders("p", "q", "cost")
+	tb.AddRowItems(2, 3, tabular.MustParseFormula("{p} * {q}"))
+	tb.AddRowItems(1, 0, tabular.MustParseFormula("{p} / {q}"))
+
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "table with formulas renders without errors")
+	T.Equal(have, `[
+{"p": 2, "q": 3, "cost": 6},
+{"p": 1, "q": 0, "cost": null}
+]
+`, "formula values emitted, failures as null")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"sort"
+)
+
+// KeyOrder says how AddMaps orders the columns made from the keys of maps.
+type KeyOrder int
+
+const (
+	// KEYS_FIRST_SEEN orders keys by the first map holding each, and the keys
+	// new in any one map by sorting them, since maps have no order of their
+	// own.
+	KEYS_FIRST_SEEN KeyOrder = iota
+
+	// KEYS_SORTED orders keys by sorting them.
+	KEYS_SORTED
+)
+
+// AddMaps adds a row for each map in maps.  If the table has no headers yet,
+// then the headers are the union of the keys of all the maps, in the given
+// order, else each item is put in the column named by its key, and a key
+// without one is an error.  A column whose key is missing from a map is
+// empty in that map's row.  Any errors accumulate in the table, which is
+// returned.
+func (t *ATable) AddMaps(maps []map[string]any, order KeyOrder) Table {
+	if t.headerRow == nil {
+		t.AddHeaders(mapKeys(maps, order)...)
+	}
+	columns := make(map[string]int)
+	for _, m := range maps {
+		for key := range m {
+			if _, ok := columns[key]; ok {
+				continue
+			}
+			n, err := columnNumberNamed(t, key)
+			if err != nil {
+				t.AddError(err)
+			}
+			columns[key] = n
+		}
+	}
+
+	for _, m := range maps {
+		cells := make([]Cell, t.nColumns)
+		for i := range cells {
+			cells[i] = NewCell(nil)
+		}
+		for key, item := range m {
+			if n := columns[key]; n > 0 {
+				cells[n-1] = cellForItem(item)
+			}
+		}
+		row := NewRowWithCapacity(len(cells))
+		for i := range cells {
+			row.Add(cells[i])
+		}
+		t.AddRow(row)
+	}
+	return t
+}
+
+// mapKeys returns the union of the keys of the maps, in the given order.
+func mapKeys(maps []map[string]any, order KeyOrder) []any {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		start := len(keys)
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys[start:])
+	}
+	if order == KEYS_SORTED {
+		sort.Strings(keys)
+	}
+	headers := make([]any, len(keys))
+	for i := range keys {
+		headers[i] = keys[i]
+	}
+	return headers
+}
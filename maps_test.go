@@ -0,0 +1,46 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+)
+
+func TestAddMaps(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	records := []map[string]any{
+		{"name": "web1", "cpu": 12},
+		{"name": "db1", "zone": "b", "cpu": 80, "disk": nil},
+		{"alarm": true, "name": "mail1"},
+	}
+
+	tb := csv.New()
+	tb.AddMaps(records, tabular.KEYS_FIRST_SEEN)
+	T.Equal(headerNames(tb), []string{"cpu", "name", "disk", "zone", "alarm"}, "keys in order first seen")
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "maps rendered")
+	T.Equal(rendered, `"cpu","name","disk","zone","alarm"
+"12","web1","","",""
+"80","db1","","b",""
+"","mail1","","","true"
+`, "missing keys empty")
+
+	tb.AddMaps([]map[string]any{{"name": "dns1", "owner": "ops"}}, tabular.KEYS_FIRST_SEEN)
+	T.Equal(tb.Errors(), []error{tabular.ErrorNoSuchColumn("owner")}, "key without a column")
+	T.Equal(columnStrings(tb, 2)[3], "dns1", "keys with columns still added")
+
+	sorted := tabular.New()
+	sorted.AddMaps(records, tabular.KEYS_SORTED)
+	T.Equal(headerNames(sorted), []string{"alarm", "cpu", "disk", "name", "zone"}, "keys sorted")
+	T.Equal(columnStrings(sorted, 4), []string{"web1", "db1", "mail1"}, "rows in order")
+	T.Equal(sorted.Errors(), nil, "no errors adding maps")
+}
@@ -1,4 +1,4 @@
-// Copyright © 2016,2018,2025 Pennock Tech, LLC.
+// Copyright © 2016,2018,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -23,6 +23,14 @@ in various formats.  Thus we have _two_ target audiences: a human reviewing
 our output as it goes into .md documents, and people viewing the rendered
 tables later.  We need to look "decent" for both, but can defer sanitization
 to the human review step.
+
+GFM tables have no way to merge cells, nor more than one row of headers.
+The names of any header groups are joined onto the front of each column
+header.  A cell which spans several columns or rows is shown in its top-left
+position only, and the other positions which it covers are left empty.
+Again, use the HTMLTable wrapper if you need these to be visible.
+Nor is there a footer, so footer rows are shown as the last rows of the
+body.
 */
 package markdown // import "go.pennock.tech/tabular/markdown"
 
@@ -42,6 +50,9 @@ import (
 // A MarkdownTable wraps a tabular.Table to act as a render control for Markdown output.
 type MarkdownTable struct {
 	tabular.Table
+
+	headerGroupSeparator string
+	titleHeadingLevel    int
 }
 
 // Wrap returns a MarkdownTable rendering object for the given tabular.Table.
@@ -49,10 +60,31 @@ func Wrap(t tabular.Table) *MarkdownTable {
 	var ws widthSetter
 	t.RegisterPropertyCallback(t, tabular.CB_AT_RENDER, tabular.CB_ON_CELL, ws)
 	return &MarkdownTable{
-		Table: t,
+		Table:                t,
+		headerGroupSeparator: tabular.DEFAULT_HEADER_GROUP_SEPARATOR,
+		titleHeadingLevel:    2,
 	}
 }
 
+// SetTitleHeadingLevel sets the level of the markdown heading used for the
+// table's title, from 1 to 6; the default is 2.  A level of 0 shows the title
+// as a paragraph of strong text instead of as a heading.  Any subtitle is
+// always a plain paragraph.  The MarkdownTable is returned, to permit
+// chaining.
+func (mt *MarkdownTable) SetTitleHeadingLevel(level int) *MarkdownTable {
+	mt.titleHeadingLevel = min(max(level, 0), 6)
+	return mt
+}
+
+// SetHeaderGroupSeparator sets the text put between the names of header
+// groups and the column header, when they are joined together to make the
+// one header row which GFM tables allow.  The MarkdownTable is returned, to
+// permit chaining.
+func (mt *MarkdownTable) SetHeaderGroupSeparator(sep string) *MarkdownTable {
+	mt.headerGroupSeparator = sep
+	return mt
+}
+
 // New returns a MarkdownTable with a new Table inside it, access via .Table
 // or just use the interface methods on the MarkdownTable.
 func New() *MarkdownTable {
@@ -116,13 +148,22 @@ func (mt *MarkdownTable) RenderTo(w io.Writer) error {
 	if headers == nil {
 		return fmt.Errorf("markdown:RenderTo: can't emit a table without headers")
 	}
+	// Flattened headers are not in the table, so have no width property.
+	flattened := mt.HeaderGroupRows() != nil
+	if flattened {
+		headers = tabular.FlattenHeaders(mt, mt.headerGroupSeparator)
+	}
 
 	widths := make([]int, columnCount)
 	if len(headers) > columnCount {
 		return fmt.Errorf("structural bug, columnCount %d but %d headers", columnCount, len(headers))
 	}
 	for i := range headers {
-		widths[i] = CellPropertyExtractWidth(&headers[i])
+		if flattened {
+			widths[i] = headers[i].TerminalCellWidth()
+		} else {
+			widths[i] = CellPropertyExtractWidth(&headers[i])
+		}
 		omit := mt.Column(i + 1).GetProperty(properties.Omit)
 		if omit != nil {
 			if omitColumns[i], err = properties.ExpectBoolPropertyOrNil(properties.Omit, omit, "markdown:RenderTo", "column", i+1); err != nil {
@@ -136,7 +177,8 @@ func (mt *MarkdownTable) RenderTo(w io.Writer) error {
 		}
 	}
 
-	for n, r := range mt.AllRows() {
+	footerRows := mt.FooterRows()
+	for n, r := range append(mt.AllRows(), footerRows...) {
 		if r.IsSeparator() {
 			continue
 		}
@@ -178,6 +220,10 @@ func (mt *MarkdownTable) RenderTo(w io.Writer) error {
 		controlRowCells = append(controlRowCells, tabular.NewCell(content))
 	}
 
+	if err = mt.emitTitles(w); err != nil {
+		return err
+	}
+
 	if err = mt.emitRow(w, columnCount, headers, omitColumns, widths, alignments, true); err != nil {
 		return err
 	}
@@ -200,9 +246,32 @@ func (mt *MarkdownTable) RenderTo(w io.Writer) error {
 			return err
 		}
 	}
+	for _, r := range footerRows {
+		if err = mt.emitRow(w, columnCount, r.Cells(), omitColumns, widths, alignments, true); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// emitTitles writes the table's title and subtitle, if any, as blocks
+// before the table.
+func (mt *MarkdownTable) emitTitles(w io.Writer) error {
+	var b bytes.Buffer
+	if title := mt.Title(); title != "" {
+		if mt.titleHeadingLevel > 0 {
+			b.WriteString(strings.Repeat("#", mt.titleHeadingLevel) + " " + mt.mdTextEscape(title) + "\n\n")
+		} else {
+			b.WriteString("**" + mt.mdTextEscape(title) + "**\n\n")
+		}
+	}
+	if subtitle := mt.Subtitle(); subtitle != "" {
+		b.WriteString(mt.mdTextEscape(subtitle) + "\n\n")
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
 // emitRow handles just one row, whether from headers or body.
 // It needs to know how many columns should be in the row, so that it can add extras,
 // or error out, as needed.
@@ -319,3 +388,9 @@ func (mt *MarkdownTable) mdPaddedCellEscape(
 func (mt *MarkdownTable) mdCellEscape(in string) string {
 	return strings.Replace(strings.Replace(html.EscapeString(in), "|", "&#x7c;", -1), "\n", "&#x0a;", -1)
 }
+
+// mdTextEscape handles text outside of the table, such as the title, which
+// must stay on one line but needs no protection for pipe characters.
+func (mt *MarkdownTable) mdTextEscape(in string) string {
+	return strings.Replace(html.EscapeString(in), "\n", " ", -1)
+}
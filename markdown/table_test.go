@@ -167,3 +167,98 @@ func TestSingleColumnMarkdown(t *testing.T) {
 	T.ExpectSuccess(err, "single-column table renders without errors")
 	T.Equal(have, should, "got correct single-column output")
 }
+
+func TestSpansMarkdown(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := markdown.New()
+	tb.AddHeaders(tabular.NewSpanningCell("latency", 2, 1), "unit")
+	tb.AddRowItems(tabular.NewSpanningCell("p50", 1, 2), 3, "ms")
+	tb.AddRowItems(4, "ms")
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := `
+| latency |   | unit |
+| ------- | --- | ---- |
+| p50     | 3 | ms   |
+|         | 4 | ms   |
+`
+	should = strings.TrimLeftFunc(should, unicode.IsSpace)
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "spanning table renders without errors")
+	T.Equal(have, should, "spanned positions other than the first are empty")
+}
+
+func TestHeaderGroupsMarkdown(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := markdown.New()
+	tb.AddHeaderGroups(tabular.HeaderGroup{Columns: 1}, tabular.HeaderGroup{Name: "Latency", Columns: 2})
+	tb.AddHeaders("host", "p50", "p99")
+	tb.AddRowItems("alpha", 3, 40)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := `
+| host  | Latency p50 | Latency p99 |
+| ----- | ----------- | ----------- |
+| alpha | 3           | 40          |
+`
+	should = strings.TrimLeftFunc(should, unicode.IsSpace)
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "grouped table renders without errors")
+	T.Equal(have, should, "group names joined onto headers")
+}
+
+func TestTitlesMarkdown(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := markdown.New()
+	tb.SetTitle("Hosts <all>")
+	tb.SetSubtitle("as of today")
+	tb.AddHeaders("host", "up")
+	tb.AddRowItems("alpha", true)
+
+	should := `
+## Hosts &lt;all&gt;
+
+as of today
+
+| host  | up   |
+| ----- | ---- |
+| alpha | true |
+`
+	should = strings.TrimLeftFunc(should, unicode.IsSpace)
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "titled table renders without errors")
+	T.Equal(have, should, "title is a heading and subtitle a paragraph")
+
+	have, err = tb.SetTitleHeadingLevel(0).Render()
+	T.ExpectSuccess(err, "titled table renders without errors, no heading")
+	T.Equal(have, strings.Replace(should, "## Hosts &lt;all&gt;", "**Hosts &lt;all&gt;**", 1), "title can be a paragraph")
+}
+
+func TestFooterMarkdown(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := markdown.New()
+	tb.AddHeaders("item", "qty")
+	tb.AddRowItems("apple", 3)
+	tb.AddRowItems("pear", 2)
+	tb.SetTotalsLabel("Total")
+	tb.Column(2).SetAggregator(tabular.AGG_SUM)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := "" +
+		"| item  | qty |\n" +
+		"| ----- | --- |\n" +
+		"| apple | 3   |\n" +
+		"| pear  | 2   |\n" +
+		"| Total | 5   |\n"
+	have, err := tb.Render()
+	T.ExpectSuccess(err, "table with totals renders without errors")
+	T.Equal(have, should, "totals row after the body")
+}
@@ -0,0 +1,125 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"go.pennock.tech/tabular/properties"
+	"go.pennock.tech/tabular/properties/collate"
+)
+
+// Pivot returns a new table summarizing this one as a matrix: there is one
+// row for each distinct value in the rowKey column and one column for each
+// distinct value in the columnKey column, and each cell holds the result of
+// the aggregator over the cells of the value column in the rows with that
+// pair of keys.  The columns are all found by name, as for ColumnNamed.
+//
+// The headers of the new table are the name of the rowKey column followed by
+// the distinct values of the columnKey column.  The keys are distinct by their
+// String form, and are ordered as an ascending SortBy on their columns would
+// order them, respecting any collation.  Where no row has a pair of keys, the
+// cell is empty, rather than the aggregator being asked about no cells at all.
+// Separators, subtotal rows and rows marked with properties.Omit are skipped.
+func (t *ATable) Pivot(rowKey, columnKey, value string, agg Aggregator) (Table, error) {
+	return pivot(t, rowKey, columnKey, value, agg)
+}
+
+// Pivot returns a new table summarizing the rows shown by the view.
+func (fv *FilterView) Pivot(rowKey, columnKey, value string, agg Aggregator) (Table, error) {
+	return pivot(fv, rowKey, columnKey, value, agg)
+}
+
+// Pivot returns a new table summarizing the projection.
+func (pv *ProjectionView) Pivot(rowKey, columnKey, value string, agg Aggregator) (Table, error) {
+	return pivot(pv, rowKey, columnKey, value, agg)
+}
+
+func pivot(t Table, rowKey, columnKey, value string, agg Aggregator) (*ATable, error) {
+	if agg == nil {
+		return nil, ErrNoAggregator
+	}
+	var keys [2]sortColumn
+	for i, name := range []string{rowKey, columnKey} {
+		n, err := columnNumberNamed(t, name)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = sortColumn{SortKey: SortKey{Name: name, Order: SORT_ASC}, index: n - 1}
+		if collation, ok := t.Column(n).GetProperty(collate.PropertyType).(collate.Collation); ok {
+			keys[i].collation = collation
+		}
+	}
+	valueColumn, err := columnNumberNamed(t, value)
+	if err != nil {
+		return nil, err
+	}
+
+	body := dataRows(t)
+	rowKeys := distinctKeys(body, keys[0])
+	columnKeys := distinctKeys(body, keys[1])
+
+	cells := make(map[[2]string][]*Cell)
+	for _, row := range body {
+		pair := [2]string{row.sortCell(keys[0].index).String(), row.sortCell(keys[1].index).String()}
+		cells[pair] = append(cells[pair], cellsToAggregate([]*Row{row}, valueColumn)...)
+	}
+
+	nt := New()
+	headers := make([]any, 1, len(columnKeys)+1)
+	headers[0] = rowKey
+	for _, ck := range columnKeys {
+		headers = append(headers, ck.String())
+	}
+	nt.AddHeaders(headers...)
+	for _, rk := range rowKeys {
+		row := NewRowWithCapacity(len(columnKeys) + 1)
+		row.Add(NewCell(rk.raw))
+		for _, ck := range columnKeys {
+			if c, ok := cells[[2]string{rk.String(), ck.String()}]; ok && len(c) > 0 {
+				row.Add(NewCell(agg.Aggregate(c)))
+			} else {
+				row.Add(NewCell(nil))
+			}
+		}
+		nt.AddRow(row)
+	}
+	return nt, nil
+}
+
+// dataRows returns the rows of the body of a table which hold data to be
+// summarized or combined: not separators, subtotal rows nor rows marked with
+// properties.Omit.
+func dataRows(t Table) []*Row {
+	var rows []*Row
+	for _, row := range t.AllRows() {
+		if row.isSeparator || row.isSubtotal {
+			continue
+		}
+		if omit, ok := row.GetProperty(properties.Omit).(bool); ok && omit {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// distinctKeys returns the first cell of each distinct key in a column of the
+// rows, sorted on that column.
+func distinctKeys(rows []*Row, key sortColumn) []*Cell {
+	seen := make(map[string]bool)
+	var firsts []*Row
+	for _, row := range rows {
+		s := row.sortCell(key.index).String()
+		if !seen[s] {
+			seen[s] = true
+			firsts = append(firsts, row)
+		}
+	}
+	newRowSorter(firsts, []sortColumn{key}).sort()
+	keyCells := make([]*Cell, len(firsts))
+	for i, row := range firsts {
+		keyCells[i] = row.sortCell(key.index)
+	}
+	return keyCells
+}
@@ -0,0 +1,60 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+	"go.pennock.tech/tabular/properties"
+)
+
+func TestPivot(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Host", "Day", "GB")
+	tb.AddRowItems("web2", "Tue", 7)
+	tb.AddRowItems("web1", "Mon", 4)
+	tb.AddSeparator()
+	tb.AddRowItems("web1", "Tue", 5)
+	tb.AddRowItems("web1", "Mon", 2)
+	tb.AddRowItems("db1", "Mon", 30)
+	tb.AddRowItems("db1", "Wed", 31)
+	tb.AddRowItems("db1", "Wed", 99)
+	tb.AllRows()[7].SetProperty(properties.Omit, true)
+
+	_, err := tb.Pivot("Host", "Week", "GB", tabular.AGG_SUM)
+	T.Equal(err, tabular.ErrorNoSuchColumn("Week"), "unknown column rejected")
+	_, err = tb.Pivot("Host", "Day", "GB", nil)
+	T.Equal(err, tabular.ErrNoAggregator, "aggregator needed")
+
+	pt, err := tb.Pivot("Host", "Day", "GB", tabular.AGG_SUM)
+	T.ExpectSuccess(err, "pivot hosts by day")
+	T.Equal(headerNames(pt), []string{"Host", "Mon", "Tue", "Wed"}, "distinct days as headers, sorted")
+	T.Equal(pt.AllRows()[0].Cells()[2].Empty(), true, "missing combination is empty")
+	rendered, err := csv.Wrap(pt).Render()
+	T.ExpectSuccess(err, "pivot rendered")
+	T.Equal(rendered, `"Host","Mon","Tue","Wed"
+"db1","30","","31"
+"web1","6","5",""
+"web2","","7",""
+`, "hosts by day, summed, omitted row skipped")
+
+	pt, err = tb.Filter(func(r *tabular.Row) bool { return r.Cells()[0].String() != "db1" }).Pivot("Day", "Host", "GB", tabular.AGG_COUNT)
+	T.ExpectSuccess(err, "pivot a view")
+	rendered, err = csv.Wrap(pt).Render()
+	T.ExpectSuccess(err, "pivoted view rendered")
+	T.Equal(rendered, `"Day","web1","web2"
+"Mon","2",""
+"Tue","1","1"
+`, "only the rows of the view counted")
+	T.Equal(tb.NRows(), 8, "table itself unchanged")
+	T.Equal(tb.Errors(), nil, "no errors pivoting")
+}
@@ -1,4 +1,4 @@
-// Copyright © 2016 Pennock Tech, LLC.
+// Copyright © 2016,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -100,6 +100,11 @@ func (row *Row) GoString() string {
 func (cell *Cell) GoString() string {
 	buf := &bytes.Buffer{}
 	fmt.Fprintf(buf, "C(%q, %d cbs)", cell.String(), debugCallbackSetCount(&cell.callbacks))
+	if cell.SpanCovered() {
+		fmt.Fprint(buf, ".Covered")
+	} else if columns, rows := cell.Span(); columns > 1 || rows > 1 {
+		fmt.Fprintf(buf, ".Span(%dx%d)", columns, rows)
+	}
 	if cell.propertyImpl.properties != nil {
 		fmt.Fprintf(buf, ".Props{%#v}", cell.propertyImpl.properties)
 	}
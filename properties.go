@@ -1,4 +1,4 @@
-// Copyright © 2016,2018 Pennock Tech, LLC.
+// Copyright © 2016,2018,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -26,6 +26,7 @@ type callbackSet struct {
 	preCellRenderTime  []PropertyCallback
 	renderTime         []PropertyCallback
 	postCellRenderTime []PropertyCallback
+	updateTime         []PropertyCallback
 }
 
 type callbackTime int
@@ -36,8 +37,6 @@ const (
 	// CB_AT_ADD called when an item is added to a container
 	CB_AT_ADD callbackTime = iota
 
-	// TODO v2: is `CB_AT_UPDATE` a distinct time, for `c.SetItem()` ?
-
 	// CB_AT_RENDER_PRECELL for callbacks registered on containers, to be
 	// called before cell's own callbacks.  Use before dimensions/etc locked
 	// down.
@@ -50,6 +49,12 @@ const (
 
 	// CB_AT_RENDER_POSTCELL is called after the cell's own callbacks.
 	CB_AT_RENDER_POSTCELL
+
+	// CB_AT_UPDATE called when the item in a cell is replaced, by
+	// Cell.SetItem or Table.SetCellAt: first for the cell, by the cell's own
+	// callbacks and then the cell-targetted callbacks of its row, column and
+	// table, then for its row, column and table themselves.
+	CB_AT_UPDATE
 )
 
 func invokePropertyCallbacks(
@@ -68,6 +73,8 @@ func invokePropertyCallbacks(
 		cbList = set.preCellRenderTime
 	case CB_AT_RENDER_POSTCELL:
 		cbList = set.postCellRenderTime
+	case CB_AT_UPDATE:
+		cbList = set.updateTime
 	default:
 		// internal function, only invoked from within this package, panic is appropriate
 		panic("unhandled callbackTime when invoking properties")
@@ -151,6 +158,8 @@ func (tb *ATable) RegisterPropertyCallback(
 		cbListPtr = &set.preCellRenderTime
 	case CB_AT_RENDER_POSTCELL:
 		cbListPtr = &set.postCellRenderTime
+	case CB_AT_UPDATE:
+		cbListPtr = &set.updateTime
 	default:
 		return fmt.Errorf("unhandled callbackTime when registering properties (%v)", when)
 	}
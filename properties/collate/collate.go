@@ -0,0 +1,104 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+// Package collate provides the orderings of text which can be set on a
+// column, as the PropertyType property, for sorting by that column.
+//
+// Orderings following the Unicode collation rules for a language are in the
+// locale package beneath this one, a module of its own so that only those
+// who use it depend upon golang.org/x/text.
+package collate // import "go.pennock.tech/tabular/properties/collate"
+
+import (
+	"strings"
+)
+
+type propertyKey struct {
+	name string
+}
+
+func (p *propertyKey) String() string { return "collation property keyid " + p.name }
+
+var (
+	PropertyType = &propertyKey{"type"}
+)
+
+// A Collation orders text: Compare returns a negative number, zero or a
+// positive number as a sorts before, with or after b.
+type Collation interface {
+	Compare(a, b string) int
+}
+
+type collateSimple struct {
+	natural bool
+	fold    bool
+}
+
+var (
+	// Bytewise compares the bytes of the text, as Go's < does for strings.
+	Bytewise Collation = collateSimple{}
+	// CaseInsensitive ignores case, falling back to bytewise comparison
+	// for text which differs only in case.
+	CaseInsensitive Collation = collateSimple{fold: true}
+	// Natural compares runs of digits by their numeric value, so that
+	// "host2" sorts before "host10".
+	Natural Collation = collateSimple{natural: true}
+	// NaturalCaseInsensitive is Natural, ignoring case as CaseInsensitive
+	// does.
+	NaturalCaseInsensitive Collation = collateSimple{natural: true, fold: true}
+)
+
+func (cs collateSimple) Compare(a, b string) int {
+	if cs.fold {
+		if c := cs.compareRuns(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+			return c
+		}
+	}
+	return cs.compareRuns(a, b)
+}
+
+func (cs collateSimple) compareRuns(a, b string) int {
+	if !cs.natural {
+		return strings.Compare(a, b)
+	}
+	leading := 0
+	for a != "" && b != "" {
+		var ra, rb string
+		ra, a = nextRun(a)
+		rb, b = nextRun(b)
+		if isDigit(ra[0]) && isDigit(rb[0]) {
+			za, zb := strings.TrimLeft(ra, "0"), strings.TrimLeft(rb, "0")
+			if len(za) != len(zb) {
+				return len(za) - len(zb)
+			}
+			if c := strings.Compare(za, zb); c != 0 {
+				return c
+			}
+			if leading == 0 {
+				// fewer leading zeroes first, but only once all else is equal
+				leading = len(ra) - len(rb)
+			}
+			continue
+		}
+		if c := strings.Compare(ra, rb); c != 0 {
+			return c
+		}
+	}
+	if c := len(a) - len(b); c != 0 {
+		return c
+	}
+	return leading
+}
+
+// nextRun splits off the leading run of digits, or of non-digits.
+func nextRun(s string) (run, rest string) {
+	digits := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(b byte) bool { return '0' <= b && b <= '9' }
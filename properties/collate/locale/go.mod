@@ -0,0 +1,8 @@
+module go.pennock.tech/tabular/properties/collate/locale
+
+go 1.23.0
+
+require (
+	github.com/liquidgecka/testlib v1.0.0
+	golang.org/x/text v0.27.0
+)
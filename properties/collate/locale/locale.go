@@ -0,0 +1,43 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+// Package locale provides orderings of text following the Unicode collation
+// rules for a language, which can be set on a column as the
+// collate.PropertyType property, as any other collate.Collation can.
+//
+// This is a module of its own, so that the tabular module itself does not
+// depend upon golang.org/x/text.
+package locale // import "go.pennock.tech/tabular/properties/collate/locale"
+
+import (
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// A Collation orders text by the rules for a language.  It must not be used
+// by more than one sort at a time.
+type Collation struct {
+	collator *collate.Collator
+}
+
+// New returns a Collation following the Unicode collation rules for the
+// language with the given BCP 47 tag, such as "en", "de" or "sv"; with
+// natural true, runs of digits compare by their numeric value.
+func New(tag string, natural bool) (Collation, error) {
+	lang, err := language.Parse(tag)
+	if err != nil {
+		return Collation{}, err
+	}
+	var options []collate.Option
+	if natural {
+		options = append(options, collate.Numeric)
+	}
+	return Collation{collate.New(lang, options...)}, nil
+}
+
+// Compare returns a negative number, zero or a positive number as a sorts
+// before, with or after b.
+func (c Collation) Compare(a, b string) int {
+	return c.collator.CompareString(a, b)
+}
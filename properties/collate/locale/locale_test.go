@@ -0,0 +1,35 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package locale_test // import "go.pennock.tech/tabular/properties/collate/locale"
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular/properties/collate/locale"
+)
+
+func sorted(c locale.Collation, items ...string) []string {
+	sort.SliceStable(items, func(i, j int) bool { return c.Compare(items[i], items[j]) < 0 })
+	return items
+}
+
+func TestLocale(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	english, err := locale.New("en", false)
+	T.ExpectSuccess(err, "English collation")
+	T.Equal(sorted(english, "Zoe", "émile", "Émile", "adam", "Zack"), []string{"adam", "émile", "Émile", "Zack", "Zoe"}, "sorted by locale")
+
+	natural, err := locale.New("en", true)
+	T.ExpectSuccess(err, "English natural collation")
+	T.Equal(sorted(natural, "host10", "host2", "Host3"), []string{"host2", "Host3", "host10"}, "sorted by natural locale")
+
+	_, err = locale.New("not a language tag", false)
+	T.ExpectError(err, "unknown language rejected")
+}
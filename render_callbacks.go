@@ -1,4 +1,4 @@
-// Copyright © 2016 Pennock Tech, LLC.
+// Copyright © 2016,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -9,11 +9,18 @@ package tabular // import "go.pennock.tech/tabular"
 //
 // We first invoke pre-cell callbacks going: table->column->row->cell
 // We then invoke regular render callbacks cell->row->column->table
+//
+// Footer rows are handled after the column post-cell callbacks, since those
+// compute the totals row from the body.
 func (t *ATable) InvokeRenderCallbacks() {
 	ec := t.ErrorContainer
 	invokePropertyCallbacks(t.tableItselfCallbacks, CB_AT_RENDER_PRECELL, t, ec)
-	for _, col := range t.columns {
-		invokePropertyCallbacks(col.columnItselfCallbacks, CB_AT_RENDER_PRECELL, &col, ec)
+	for i := range t.columns {
+		col := &t.columns[i]
+		invokePropertyCallbacks(col.columnItselfCallbacks, CB_AT_RENDER_PRECELL, col, ec)
+	}
+	for _, row := range t.headerGroupRows {
+		row.invokeRenderCallbacks(t, ec)
 	}
 	if t.headerRow != nil {
 		t.headerRow.invokeRenderCallbacks(t, ec)
@@ -21,8 +28,12 @@ func (t *ATable) InvokeRenderCallbacks() {
 	for _, row := range t.rows {
 		row.invokeRenderCallbacks(t, ec)
 	}
-	for _, col := range t.columns {
-		invokePropertyCallbacks(col.columnItselfCallbacks, CB_AT_RENDER_POSTCELL, &col, ec)
+	for i := range t.columns {
+		col := &t.columns[i]
+		invokePropertyCallbacks(col.columnItselfCallbacks, CB_AT_RENDER_POSTCELL, col, ec)
+	}
+	for _, row := range t.FooterRows() {
+		row.invokeRenderCallbacks(t, ec)
 	}
 	invokePropertyCallbacks(t.tableItselfCallbacks, CB_AT_RENDER_POSTCELL, t, ec)
 }
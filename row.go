@@ -1,4 +1,4 @@
-// Copyright © 2016 Pennock Tech, LLC.
+// Copyright © 2016,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -17,7 +17,9 @@ type Row struct {
 	rowItselfCallbacks callbackSet
 	inTable            *ATable
 	isSeparator        bool
+	isSubtotal         bool
 	rowNum             int
+	columnNames        map[string]int // for a row of a projection, its own columns
 }
 
 // AddError records that an error has happened when dealing with a row.
@@ -57,18 +59,29 @@ func (t *ATable) AppendNewRow() *Row {
 
 // Add adds one cell to this row, and returns the row for chaining, thus
 // r.Add(c1).Add(c2).Add(c3)
+// If the cell spans multiple columns then placeholder cells for the
+// covered columns are added too.
 func (r *Row) Add(c Cell) *Row {
 	if r.cells == nil {
 		r.AddError(errors.New("can't add cells to a non-cell row"))
 		return r
 	}
+	c.spanFrom = spanOrigin{}
+	r.addCell(c)
+	column := len(r.cells)
+	for i := 1; i < c.span.columns; i++ {
+		r.addCell(newPlaceholderCell(r, column))
+	}
+	return r
+}
+
+func (r *Row) addCell(c Cell) {
 	r.cells = append(r.cells, c)
 	column := len(r.cells)
 	ptr := &r.cells[column-1]
 	ptr.inRow = r
 	ptr.columnNum = column
 	invokePropertyCallbacks(r.rowCellCallbacks, CB_AT_ADD, ptr, r.ErrorContainer)
-	return r
 }
 
 // Cells returns an iterable of the cells in a row.  If it returns nil
@@ -80,15 +93,126 @@ func (r *Row) Cells() []Cell {
 }
 
 // AddRowItems creates a row from the passed items and adds it to the table, returning
-// the table for chaining.
+// the table for chaining.  Any item which is a Cell spanning columns or rows
+// is added as that cell; any other Cell is held by a new cell, as any other
+// item is.  Any item which is a Fielder or AnonFielder becomes a cell for each field.
 func (t *ATable) AddRowItems(items ...any) Table {
 	r := NewRowWithCapacity(len(items))
 	for i := range items {
-		r.Add(NewCell(items[i]))
+		r.Add(cellForItem(items[i]))
 	}
 	return t.AddRow(r)
 }
 
+// ErrRowNotInTable is returned when a row which is in no table is asked for
+// something found through the table, such as a column name.
+var ErrRowNotInTable = errors.New("row is not in a table")
+
+// CellNamed returns the cell of the row in the column with the given name,
+// per the headers of the table the row is in.  For a column covered by a cell
+// spanning columns or rows, the spanning cell is returned.
+func (r *Row) CellNamed(name string) (*Cell, error) {
+	columnNames, err := r.namedColumns()
+	if err != nil {
+		return nil, err
+	}
+	i, ok := columnNames[name]
+	if !ok {
+		return nil, ErrorNoSuchColumn(name)
+	}
+	if i >= len(r.cells) {
+		return nil, NoSuchCellError{Location: CellLocation{Row: r.rowNum, Column: i + 1}}
+	}
+	return r.cells[i].SpanAnchor(), nil
+}
+
+// AsMap returns the items of the cells of the row, per Cell.Item, keyed by
+// the names of their columns in the table the row is in.  Columns beyond the
+// end of a short row are left out; for a column covered by a cell spanning
+// columns or rows, the item is that of the spanning cell.  For a separator,
+// or a row not in a table with headers, nil is returned.
+func (r *Row) AsMap() map[string]any {
+	columnNames, err := r.namedColumns()
+	if r.cells == nil || err != nil {
+		return nil
+	}
+	m := make(map[string]any, len(columnNames))
+	for name, i := range columnNames {
+		if i < len(r.cells) {
+			m[name] = r.cells[i].SpanAnchor().Item()
+		}
+	}
+	return m
+}
+
+// namedColumns returns the index of each column of the row by name: for a
+// row seen through a projection, that of the projection's columns, else that
+// of the table the row is in.
+func (r *Row) namedColumns() (map[string]int, error) {
+	switch {
+	case r.columnNames != nil:
+		return r.columnNames, nil
+	case r.inTable == nil:
+		return nil, ErrRowNotInTable
+	case r.inTable.columnNames == nil:
+		return nil, ErrNoColumnHeaders
+	}
+	return r.inTable.columnNames, nil
+}
+
+// fielderItems returns the items which an item describes through Fielder or
+// AnonFielder, preferring AnonFielder, or false if it satisfies neither.
+func fielderItems(item any) ([]any, bool) {
+	switch o := item.(type) {
+	case AnonFielder:
+		return o.AnonFields(), true
+	case Fielder:
+		fields := o.Fields()
+		items := make([]any, len(fields))
+		for i := range fields {
+			items[i] = fields[i]
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// expandFielders replaces each cell of the row which holds a Fielder or
+// AnonFielder with a cell for each of its fields, before the row is put into
+// a table.  The new cells are passed to the row's addition-time callbacks.
+func (r *Row) expandFielders() {
+	i := 0
+	for ; i < len(r.cells); i++ {
+		if _, ok := fielderItems(r.cells[i].raw); ok && r.cells[i].spanFrom.row == nil {
+			break
+		}
+	}
+	if i == len(r.cells) {
+		return
+	}
+	old := r.cells
+	r.cells = make([]Cell, 0, len(old)+4)
+	for j := range old {
+		if old[j].spanFrom.row != nil {
+			// placeholders for cells spanning columns are made afresh
+			continue
+		}
+		items, ok := fielderItems(old[j].raw)
+		if !ok {
+			column := len(r.cells) + 1
+			r.cells = append(r.cells, old[j])
+			for k := 1; k < old[j].span.columns; k++ {
+				r.cells = append(r.cells, newPlaceholderCell(r, column))
+			}
+			continue
+		}
+		for k := range items {
+			r.addCell(cellForItem(items[k]))
+		}
+	}
+	r.renumberCells()
+}
+
 // newSeparator returns a separator row.
 // Open Question for v2:
 //
@@ -99,3 +223,8 @@ func newSeparator() *Row { return &Row{isSeparator: true} }
 func (r *Row) IsSeparator() bool {
 	return r.isSeparator
 }
+
+// IsSubtotal is true iff a row is a subtotal row added by GroupBy.
+func (r *Row) IsSubtotal() bool {
+	return r.isSubtotal
+}
@@ -0,0 +1,166 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"strconv"
+)
+
+// Editing the body
+//
+// Beyond appending, rows of the body can be inserted, removed, moved and
+// replaced.  Positions count from 1, as for CellLocation, and separators
+// count as rows.  After each change, every row knows its new position, and
+// spans are recomputed: a row placed beneath a cell spanning rows has its
+// cells flow around the covered positions, as for AddRow, while positions
+// which are no longer covered become empty cells.  Rows taken out of the
+// table lose the placeholders for cells above which spanned into them, so
+// can be added again elsewhere.  MoveRow is the exception, refusing to move a
+// row into, out of or within the rows joined by a cell spanning rows.
+
+type ErrorRowOutOfRange int
+
+func (e ErrorRowOutOfRange) Error() string {
+	return "row " + strconv.Itoa(int(e)) + " out of range"
+}
+
+type ErrorRowInSpan int
+
+func (e ErrorRowInSpan) Error() string {
+	return "row " + strconv.Itoa(int(e)) + " is within a cell spanning rows"
+}
+
+// InsertRowAt adds a *Row to the body of the table so that it becomes the
+// row at the given position, moving the rows from there onwards down by one.
+// A position of NRows()+1 appends, as AddRow does.  The addition-time
+// callbacks are invoked as for AddRow.
+func (t *ATable) InsertRowAt(position int, row *Row) error {
+	if position < 1 || position > len(t.rows)+1 {
+		return ErrorRowOutOfRange(position)
+	}
+	if position == len(t.rows)+1 {
+		t.AddRow(row)
+		return nil
+	}
+	t.placeRow(position, row)
+	t.resizeColumnsAtLeast(len(row.cells))
+	t.adoptRow(row)
+	return nil
+}
+
+// RemoveRow takes the row at the given position out of the body of the
+// table, moving the rows beneath it up by one, and returns it.
+func (t *ATable) RemoveRow(position int) (*Row, error) {
+	if position < 1 || position > len(t.rows) {
+		return nil, ErrorRowOutOfRange(position)
+	}
+	row := t.takeRow(position)
+	t.recomputeSpans()
+	return row, nil
+}
+
+// MoveRow moves the row at one position of the body of the table so that it
+// is at another, with the rows between shifting to make room.  No
+// addition-time callbacks are invoked, since the row is already in the
+// table.  Rather than break a cell spanning rows, the move is refused with
+// ErrorRowInSpan if the row holds such a cell or is covered by one, or if it
+// would land between the rows which one covers.
+func (t *ATable) MoveRow(from, to int) error {
+	if from < 1 || from > len(t.rows) {
+		return ErrorRowOutOfRange(from)
+	}
+	if to < 1 || to > len(t.rows) {
+		return ErrorRowOutOfRange(to)
+	}
+	if from == to {
+		return nil
+	}
+	if row := t.rows[from-1]; row.spansBelow() || row.coveredFromAbove() {
+		return ErrorRowInSpan(from)
+	}
+	// The row lands above the one now at to, or beneath it when moving down.
+	below := to
+	if to > from {
+		below = to + 1
+	}
+	if below <= len(t.rows) && t.rows[below-1].coveredFromAbove() {
+		return ErrorRowInSpan(to)
+	}
+	row := t.takeRow(from)
+	t.recomputeSpans()
+	t.placeRow(to, row)
+	row.ErrorContainer = t.ErrorContainer
+	return nil
+}
+
+// ReplaceRow puts a *Row into the body of the table in place of the row at
+// the given position, returning the row replaced.  The addition-time
+// callbacks are invoked for the new row, as for AddRow.
+func (t *ATable) ReplaceRow(position int, row *Row) (*Row, error) {
+	if position < 1 || position > len(t.rows) {
+		return nil, ErrorRowOutOfRange(position)
+	}
+	old := t.takeRow(position)
+	t.recomputeSpans()
+	t.placeRow(position, row)
+	t.resizeColumnsAtLeast(len(row.cells))
+	t.adoptRow(row)
+	return old, nil
+}
+
+// Truncate removes every row of the body of the table after the first
+// count rows; a count of 0 empties the body.
+func (t *ATable) Truncate(count int) error {
+	if count < 0 || count > len(t.rows) {
+		return ErrorRowOutOfRange(count)
+	}
+	for len(t.rows) > count {
+		t.takeRow(len(t.rows))
+	}
+	t.recomputeSpans()
+	return nil
+}
+
+// placeRow inserts a row at a position within, or at the end of, the body,
+// which must have correct row numbers, then recomputes the spans.
+func (t *ATable) placeRow(position int, row *Row) {
+	row.expandFielders()
+	t.flowAroundRowSpans(row, position)
+	t.rows = append(t.rows, nil)
+	copy(t.rows[position:], t.rows[position-1:])
+	t.rows[position-1] = row
+	row.inTable = t
+	t.recomputeSpans()
+}
+
+// takeRow removes the row at a position of the body and detaches it from
+// the table; the caller must recompute the spans of the table.
+func (t *ATable) takeRow(position int) *Row {
+	row := t.rows[position-1]
+	copy(t.rows[position-1:], t.rows[position:])
+	t.rows[len(t.rows)-1] = nil
+	t.rows = t.rows[:len(t.rows)-1]
+	row.inTable = nil
+	row.rowNum = 0
+	row.ErrorContainer = nil
+	if row.cells == nil {
+		return row
+	}
+	cells := make([]Cell, 0, len(row.cells))
+	for _, c := range row.cells {
+		if c.spanFrom.row != nil && c.spanFrom.row != row {
+			continue
+		}
+		cells = append(cells, c)
+	}
+	for i := range cells {
+		cells[i].inRow = row
+		cells[i].columnNum = i + 1
+		cells[i].spanFrom = spanOrigin{}
+	}
+	row.cells = cells
+	markColumnSpans(row)
+	return row
+}
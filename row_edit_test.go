@@ -0,0 +1,140 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+)
+
+type countingCallback struct{ count int }
+
+func (cc *countingCallback) UpdateProperties(tabular.PropertyOwner) error {
+	cc.count++
+	return nil
+}
+
+func firstColumn(tb tabular.Table) []string {
+	rows := tb.AllRows()
+	r := make([]string, len(rows))
+	for i, row := range rows {
+		if row.IsSeparator() {
+			r[i] = "--"
+			continue
+		}
+		r[i] = row.Cells()[0].String()
+	}
+	return r
+}
+
+func checkLocations(T *testlib.T, tb tabular.Table) {
+	for i, row := range tb.AllRows() {
+		T.Equalf(row.Location().Row, i+1, "row %d knows its position", i+1)
+		for j, c := range row.Cells() {
+			T.Equalf(c.Location(), tabular.CellLocation{Row: i + 1, Column: j + 1}, "cell [%d,%d] knows its location", i+1, j+1)
+		}
+	}
+}
+
+func TestRowEditing(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	added := &countingCallback{}
+	T.ExpectSuccess(tb.RegisterPropertyCallback(tb, tabular.CB_AT_ADD, tabular.CB_ON_ROW, added), "register callback")
+	tb.AddHeaders("job", "state")
+	tb.AddRowItems("a", "running")
+	tb.AddRowItems("b", "queued")
+	tb.AddSeparator()
+	tb.AddRowItems("c", "done")
+	T.Equal(added.count, 4, "headers and rows added")
+
+	T.ExpectSuccess(tb.InsertRowAt(1, tabular.NewRow().Add(tabular.NewCell("z")).Add(tabular.NewCell("new"))), "insert at top")
+	T.Equal(firstColumn(tb), []string{"z", "a", "b", "--", "c"}, "inserted at top")
+	T.ExpectSuccess(tb.InsertRowAt(6, tabular.NewRow().Add(tabular.NewCell("y"))), "insert at end")
+	T.Equal(firstColumn(tb), []string{"z", "a", "b", "--", "c", "y"}, "inserted at end")
+	T.Equal(added.count, 6, "insertion invokes addition callbacks")
+	checkLocations(T, tb)
+
+	row, err := tb.RemoveRow(3)
+	T.ExpectSuccess(err, "remove row")
+	T.Equal(row.Cells()[0].String(), "b", "removed row returned")
+	T.Equal(row.Location().Row, 0, "removed row has no position")
+	T.Equal(firstColumn(tb), []string{"z", "a", "--", "c", "y"}, "row removed")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.MoveRow(1, 5), "move row down")
+	T.Equal(firstColumn(tb), []string{"a", "--", "c", "y", "z"}, "row moved down")
+	T.ExpectSuccess(tb.MoveRow(4, 2), "move row up")
+	T.Equal(firstColumn(tb), []string{"a", "y", "--", "c", "z"}, "row moved up")
+	T.Equal(added.count, 6, "moving doesn't invoke addition callbacks")
+	checkLocations(T, tb)
+
+	old, err := tb.ReplaceRow(2, row)
+	T.ExpectSuccess(err, "replace row")
+	T.Equal(old.Cells()[0].String(), "y", "replaced row returned")
+	T.Equal(firstColumn(tb), []string{"a", "b", "--", "c", "z"}, "row replaced")
+	T.Equal(added.count, 7, "replacement invokes addition callbacks")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.Truncate(2), "truncate")
+	T.Equal(firstColumn(tb), []string{"a", "b"}, "truncated")
+	checkLocations(T, tb)
+
+	T.Equal(tb.InsertRowAt(4, tabular.NewRow()), tabular.ErrorRowOutOfRange(4), "insert out of range")
+	_, err = tb.RemoveRow(0)
+	T.Equal(err, tabular.ErrorRowOutOfRange(0), "remove out of range")
+	T.Equal(tb.MoveRow(1, 3), tabular.ErrorRowOutOfRange(3), "move out of range")
+	_, err = tb.ReplaceRow(3, tabular.NewRow())
+	T.Equal(err, tabular.ErrorRowOutOfRange(3), "replace out of range")
+	T.Equal(tb.Truncate(3), tabular.ErrorRowOutOfRange(3), "truncate out of range")
+	T.Equal(tb.Errors(), nil, "no errors editing rows")
+}
+
+func TestRowEditingSpans(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddRowItems(tabular.NewSpanningCell("tall", 1, 3), "a1", "a2")
+	tb.AddRowItems("b1", "b2")
+	tb.AddRowItems("c1", "c2")
+	tb.AddRowItems("d0", "d1", "d2")
+
+	// Inserting within a span flows the new row around it; the last row
+	// which was covered drops out of the span.
+	T.ExpectSuccess(tb.InsertRowAt(2, tabular.NewRow().Add(tabular.NewCell("n1")).Add(tabular.NewCell("n2"))), "insert within span")
+	cells := tb.AllRows()[1].Cells()
+	T.Equal(len(cells), 3, "inserted row flowed around span")
+	T.Equal(cells[0].SpanAnchor().String(), "tall", "inserted row covered by span")
+	T.Equal(cells[1].String(), "n1", "inserted row's cells moved along")
+	T.Equal(tb.AllRows()[3].Cells()[0].SpanCovered(), false, "row beyond the span no longer covered")
+	_, rows := tb.AllRows()[0].Cells()[0].Span()
+	T.Equal(rows, 3, "span covers the rows requested")
+	checkLocations(T, tb)
+
+	// Removing the anchor's row uncovers the rows beneath.
+	row, err := tb.RemoveRow(1)
+	T.ExpectSuccess(err, "remove anchor row")
+	T.Equal(len(row.Cells()), 3, "removed row keeps its own cells")
+	T.Equal(tb.AllRows()[0].Cells()[0].SpanCovered(), false, "placeholder uncovered")
+
+	// Moving a row into, out of or within a span is refused, rather than
+	// hiding the cells of another row beneath the spanning cell.
+	T.ExpectSuccess(tb.InsertRowAt(1, row), "put anchor row back")
+	T.Equal(tb.AllRows()[1].Cells()[0].SpanAnchor().String(), "tall", "span restored")
+	T.Equal(tb.MoveRow(2, 4), tabular.ErrorRowInSpan(2), "move covered row out of span")
+	T.Equal(tb.MoveRow(1, 5), tabular.ErrorRowInSpan(1), "move anchor row")
+	T.Equal(tb.MoveRow(5, 2), tabular.ErrorRowInSpan(2), "move row into span")
+	T.ExpectSuccess(tb.MoveRow(5, 4), "move row beneath span")
+	T.Equal(columnStrings(tb, 2), []string{"a1", "n1", "b1", "d1", "c1"}, "only the row beneath moved")
+	T.Equal(tb.AllRows()[2].Cells()[0].SpanAnchor().String(), "tall", "span kept")
+	checkLocations(T, tb)
+	T.Equal(tb.Errors(), nil, "no errors editing rows with spans")
+}
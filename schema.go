@@ -0,0 +1,179 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.pennock.tech/tabular/properties/align"
+)
+
+// A ColumnSpec declares a column of a table up front: its name and how its
+// cells should be shown and checked.  Every field but Name is optional.
+//
+// Type is the Go type which the items of the column's cells should be
+// assignable to; Align and Format are as for the options of AddStructs;
+// Default is the item put in a cell which is empty when added; and Required
+// says that an empty cell is an error, if there is no Default.
+type ColumnSpec struct {
+	Name     string
+	Type     reflect.Type
+	Align    align.Alignment
+	Format   string
+	Default  any
+	Required bool
+}
+
+// A Schema declares the columns of a table, in order.
+type Schema []ColumnSpec
+
+// ErrorSchemaViolation is recorded for a cell added to a table which does not
+// fit the ColumnSpec of its column.
+type ErrorSchemaViolation struct {
+	Location CellLocation
+	Column   string
+	Problem  string
+}
+
+func (e ErrorSchemaViolation) Error() string {
+	return fmt.Sprintf("tabular: cell at [row %d, col %d] in column %q: %s", e.Location.Row, e.Location.Column, e.Column, e.Problem)
+}
+
+// SetSchema applies a schema to the table.  If the table has no headers yet,
+// then the headers are the names of the columns of the schema, else each
+// ColumnSpec applies to the column with its name, and a name without one is
+// an error.  The alignment of each column is set, and from then on each cell
+// added to the body of the table, including those already there, is given
+// its column's default if empty, checked against its column's type and
+// requirement, and formatted.  A row too short to reach a column with a
+// default, or which is required, is first extended with empty cells.  Errors
+// for cells which do not fit accumulate in the table, as ErrorSchemaViolation,
+// and the table is returned.  Cells changed by Cell.SetItem are checked
+// likewise.
+//
+// Setting a schema again replaces the specs of the columns named in it.
+func (t *ATable) SetSchema(schema Schema) Table {
+	if t.headerRow == nil {
+		names := make([]any, len(schema))
+		for i := range schema {
+			names[i] = schema[i].Name
+		}
+		t.AddHeaders(names...)
+	}
+	if !t.schemaSet {
+		if err := t.RegisterPropertyCallback(t, CB_AT_ADD, CB_ON_ROW, schemaRowCallback{}); err != nil {
+			t.AddError(err)
+			return t
+		}
+		t.schemaSet = true
+	}
+	for i := range schema {
+		n, err := columnNumberNamed(t, schema[i].Name)
+		if err != nil {
+			t.AddError(err)
+			continue
+		}
+		column := &t.columns[n]
+		spec := schema[i]
+		column.spec = &spec
+		if spec.Align != nil {
+			column.SetProperty(align.PropertyType, spec.Align)
+		}
+		if !column.checking {
+			// Registered just once; the callback finds the current spec
+			// when invoked.
+			if err := t.RegisterPropertyCallback(column, CB_AT_ADD, CB_ON_CELL, schemaCallback{}); err != nil {
+				t.AddError(err)
+				continue
+			}
+			if err := t.RegisterPropertyCallback(column, CB_AT_UPDATE, CB_ON_CELL, schemaCallback{}); err != nil {
+				t.AddError(err)
+				continue
+			}
+			column.checking = true
+		}
+		for _, row := range t.rows {
+			_ = (schemaRowCallback{}).UpdateProperties(row)
+			if n <= len(row.cells) {
+				if err := (schemaCallback{}).UpdateProperties(&row.cells[n-1]); err != nil {
+					t.AddError(err)
+				}
+			}
+		}
+	}
+	return t
+}
+
+// Spec returns the ColumnSpec set for the column by SetSchema, or nil.
+func (c *Column) Spec() *ColumnSpec {
+	return c.spec
+}
+
+// schemaRowCallback is the addition-time callback which extends a row of the
+// body to reach every column with a default or which is required, so that
+// the cells there are seen by schemaCallback.
+type schemaRowCallback struct{}
+
+func (schemaRowCallback) UpdateProperties(owner PropertyOwner) error {
+	r, ok := owner.(*Row)
+	if !ok || r.cells == nil || r.isSubtotal || r.inTable == nil {
+		return nil
+	}
+	t := r.inTable
+	reach := len(r.cells)
+	for n := len(r.cells) + 1; n <= t.nColumns; n++ {
+		if spec := t.columns[n].spec; spec != nil && (spec.Default != nil || spec.Required) {
+			reach = n
+		}
+	}
+	for len(r.cells) < reach {
+		r.addCell(NewCell(nil))
+	}
+	return nil
+}
+
+// schemaCallback is the addition-time and update-time callback which applies
+// a column's spec to each cell added to the body, or changed within it.
+type schemaCallback struct{}
+
+func (schemaCallback) UpdateProperties(owner PropertyOwner) error {
+	c, ok := owner.(*Cell)
+	if !ok || c.spanFrom.row != nil || c.inRow == nil || c.inRow.isSubtotal {
+		return nil
+	}
+	column := c.columnOfTable()
+	if column == nil || column.spec == nil {
+		return nil
+	}
+	if _, ok := c.innermost().raw.(*Formula); ok {
+		// checked, if at all, by whatever the formula reads
+		return nil
+	}
+	spec := column.spec
+	violation := func(problem string) error {
+		return ErrorSchemaViolation{Location: c.Location(), Column: spec.Name, Problem: problem}
+	}
+
+	if c.Empty() {
+		if spec.Default == nil {
+			if spec.Required {
+				return violation("required but empty")
+			}
+			return nil
+		}
+		c.raw = spec.Default
+		c.Update()
+	}
+	item := c.innermost().raw
+	if spec.Type != nil && !reflect.TypeOf(item).AssignableTo(spec.Type) {
+		return violation(fmt.Sprintf("holds %T, not %s", item, spec.Type))
+	}
+	if spec.Format != "" {
+		c.format = spec.Format
+		c.Update()
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/properties/align"
+	"go.pennock.tech/tabular/texttable"
+)
+
+func TestSchema(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := texttable.New()
+	_, err := tb.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	tb.SetSchema(tabular.Schema{
+		{Name: "Host", Type: reflect.TypeOf(""), Required: true},
+		{Name: "Load", Type: reflect.TypeOf(0.0), Align: align.Right, Format: "%.2f"},
+		{Name: "State", Default: "unknown"},
+	})
+	T.Equal(headerNames(tb), []string{"Host", "Load", "State"}, "headers from schema")
+	T.Equal(tb.Column(2).GetProperty(align.PropertyType), align.Right, "alignment from schema")
+	T.Equal(tb.Column(2).Spec().Format, "%.2f", "spec kept on column")
+
+	tb.AddRowItems("web1", 0.5, "up")
+	tb.AddRowItems("web2", 12.25)
+	tb.AddRowItems(nil, "high", "down")
+	T.Equal(tb.Errors(), []error{
+		tabular.ErrorSchemaViolation{Location: tabular.CellLocation{Row: 3, Column: 1}, Column: "Host", Problem: "required but empty"},
+		tabular.ErrorSchemaViolation{Location: tabular.CellLocation{Row: 3, Column: 2}, Column: "Load", Problem: "holds string, not float64"},
+	}, "mismatches reported with locations")
+
+	tb.Column(2).SetAggregator(tabular.AGG_SUM)
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Load", Order: tabular.SORT_DESC}), "sort formatted column")
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "schema table rendered")
+	T.Equal(rendered, strings.TrimLeft(`
++------+-------+---------+
+| Host |  Load | State   |
++------+-------+---------+
+| web2 | 12.25 | unknown |
+| web1 |  0.50 | up      |
+|      |  high | down    |
++------+-------+---------+
+|      | 12.75 |         |
++------+-------+---------+
+`, "\n"), "schema table rendered")
+	T.Equal(tb.AllRows()[0].AsMap(), map[string]any{"Host": "web2", "Load": 12.25, "State": "unknown"}, "formatted cells hold their items")
+	T.Equal(tb.AllRows()[1].Cells()[1].Format(), "%.2f", "format kept on cell")
+
+	cl := tb.Clone(false)
+	cl.AddRowItems("web3", 1.0)
+	T.Equal(cl.AllRows()[3].Cells()[1].String(), "1.00", "schema applied in clone")
+
+	tb.SetSchema(tabular.Schema{{Name: "State", Default: "n/a"}, {Name: "Owner"}})
+	T.Equal(tb.AllRows()[1].Cells()[2].String(), "up", "cells with values kept")
+	T.Equal(tb.Errors()[2], tabular.ErrorNoSuchColumn("Owner"), "unknown column in schema")
+
+	later := tabular.New()
+	later.AddHeaders("Name", "Role")
+	later.AddRowItems("alpha")
+	later.SetSchema(tabular.Schema{{Name: "Role", Default: "n/a"}})
+	T.Equal(columnStrings(later, 2), []string{"n/a"}, "schema applied to existing rows")
+	T.Equal(later.Errors(), nil, "no errors for rows fitting the schema")
+}
@@ -1,4 +1,4 @@
-// Copyright © 2025 Pennock Tech, LLC.
+// Copyright © 2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"sort"
 	"strconv"
+
+	"go.pennock.tech/tabular/properties/collate"
 )
 
 type ErrorNoSuchColumn string
@@ -40,6 +42,37 @@ func (so SortOrder) String() string {
 	}
 }
 
+// NullsOrder says where empty cells are placed by SortBy.  The zero value,
+// NULLS_AS_VALUES, compares them as any other cell, so that a cell holding
+// nothing sorts before every cell with a value, and after them when
+// descending.
+type NullsOrder int
+
+const (
+	NULLS_AS_VALUES NullsOrder = iota
+	NULLS_FIRST
+	NULLS_LAST
+)
+
+// A SortKey is one of the keys by which SortBy orders rows.  The column is
+// found by Name if that is set, else by Column, counting from 1.  The zero
+// value of Order is taken to be SORT_ASC.  Less, if set, replaces
+// Cell.LessThan for comparing the cells of the column; it is given the cells
+// as held in the rows, and with NULLS_AS_VALUES is also given empty cells.
+//
+// If Less is not set and the column has a collate.PropertyType property
+// holding a collate.Collation, then the cells are compared as text, in the
+// order of that collation.
+type SortKey struct {
+	Column int
+	Name   string
+	Order  SortOrder
+	Nulls  NullsOrder
+	Less   func(a, b *Cell) bool
+}
+
+var ErrUnknownSortOrder = errors.New("unknown sort order")
+
 // SortByNamedColumn performs an in-place row-sort.
 func (t *ATable) SortByNamedColumn(name string, order SortOrder) error {
 	if t.columnNames == nil {
@@ -52,31 +85,284 @@ func (t *ATable) SortByNamedColumn(name string, order SortOrder) error {
 	return t.SortByColumnNumber(columnNumber, order)
 }
 
-type tableSorter struct {
-	tb     *ATable
-	column int
-	order  SortOrder
+// SortByColumnNumber performs an in-place row-sort; unlike other methods,
+// the column number here counts from 0.
+func (t *ATable) SortByColumnNumber(sortCol int, order SortOrder) error {
+	if sortCol < 0 || sortCol >= t.nColumns {
+		return ErrorColumnOutOfRange(sortCol)
+	}
+	return t.SortBy(SortKey{Column: sortCol + 1, Order: order})
 }
 
-func (t tableSorter) Len() int { return t.tb.NRows() }
-func (t tableSorter) Swap(i, j int) {
-	t.tb.rows[i], t.tb.rows[j] = t.tb.rows[j], t.tb.rows[i]
-}
-func (t tableSorter) Less(i, j int) bool {
-	if t.order == SORT_ASC {
-		return t.tb.rows[i].cells[t.column].LessThan(&t.tb.rows[j].cells[t.column])
-	} else if t.order == SORT_DESC {
-		return t.tb.rows[j].cells[t.column].LessThan(&t.tb.rows[i].cells[t.column])
-	} else {
-		panic("unhandled order in tableSorter.Less")
+// SortBy performs an in-place row-sort on one or more keys: rows are ordered
+// by the first key, rows which are equal on that by the second key, and so
+// on.  The sort is stable, so rows which are equal on every key keep their
+// order, and sorting by one key and then by another gives the same result as
+// sorting by both keys, the latter first.
+//
+// Separators divide the body into groups, and rows are sorted only within
+// their group, with the separators, and any subtotal rows from GroupBy,
+// staying where they are.  A row too short to have a cell in a key's column
+// sorts as though the cell were empty.  A cell holding nil sorts before every
+// cell holding something, unless Nulls says otherwise.  Rows joined by a cell
+// spanning rows are kept together, in their order, and sort as one by the
+// first of them, which holds the spanning cell.
+func (t *ATable) SortBy(keys ...SortKey) error {
+	columns, err := t.sortColumns(keys)
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+	for _, group := range t.sortGroups() {
+		newRowSorter(group.rows, columns).sort()
 	}
+	t.recomputeSpans()
+	return nil
 }
 
-func (t *ATable) SortByColumnNumber(sortCol int, order SortOrder) error {
-	if sortCol < 0 || sortCol >= t.nColumns {
-		return ErrorColumnOutOfRange(sortCol)
+// SortGroupsBy sorts the rows within each group, as SortBy does, and then
+// sorts the groups themselves, by their first rows on the same keys.  The
+// separators stay where they are, so the groups trade places between them;
+// empty groups, from adjacent separators or a separator at the start or end
+// of the body, also stay where they are.  Subtotal rows move with the group
+// which they follow.
+func (t *ATable) SortGroupsBy(keys ...SortKey) error {
+	columns, err := t.sortColumns(keys)
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+	groups := t.sortGroups()
+	sorters := make([]rowSorter, len(groups))
+	order := make([]int, len(groups))
+	claimed := make(map[*Row]bool)
+	for i := range groups {
+		sorters[i] = newRowSorter(groups[i].rows, columns)
+		sorters[i].sort()
+		order[i] = i
+		for _, row := range groups[i].trailing {
+			claimed[row] = true
+		}
 	}
-	ts := tableSorter{t, sortCol, order}
-	sort.Sort(ts)
+	sort.SliceStable(order, func(i, j int) bool {
+		return lessOnKeys(&sorters[order[i]].entries[0], &sorters[order[j]].entries[0], columns)
+	})
+	rows := make([]*Row, 0, len(t.rows))
+	next := 0
+	for _, row := range t.rows {
+		switch {
+		case next < len(groups) && row == groups[next].rows[0]:
+			// the first row of a group: the group in this place goes here
+			rows = append(rows, groups[order[next]].rows...)
+			rows = append(rows, groups[order[next]].trailing...)
+			next++
+		case row.isSeparator || (row.isSubtotal && !claimed[row]):
+			rows = append(rows, row)
+		}
+	}
+	t.rows = rows
+	t.recomputeSpans()
 	return nil
 }
+
+// sortColumns resolves the keys for a sort into the columns they sort on.
+func (t *ATable) sortColumns(keys []SortKey) ([]sortColumn, error) {
+	columns := make([]sortColumn, len(keys))
+	for i := range keys {
+		column, err := t.sortKeyColumn(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = sortColumn{SortKey: keys[i], index: column - 1}
+		if collation, ok := t.columns[column].GetProperty(collate.PropertyType).(collate.Collation); ok {
+			columns[i].collation = collation
+		}
+		switch columns[i].Order {
+		case 0:
+			columns[i].Order = SORT_ASC
+		case SORT_ASC, SORT_DESC:
+		default:
+			return nil, ErrUnknownSortOrder
+		}
+	}
+	return columns, nil
+}
+
+// A sortGroup is a run of rows of the body which are sorted together, with
+// any subtotal rows which follow it.
+type sortGroup struct {
+	rows     []*Row // a sub-slice of the rows of the table
+	trailing []*Row
+}
+
+// sortGroups returns the runs of rows of the body between separators and
+// subtotal rows, omitting empty runs.
+func (t *ATable) sortGroups() []sortGroup {
+	var groups []sortGroup
+	start := 0
+	attach := false
+	for i := 0; i <= len(t.rows); i++ {
+		if i < len(t.rows) && !t.rows[i].isSeparator && !t.rows[i].isSubtotal {
+			continue
+		}
+		if i > start {
+			groups = append(groups, sortGroup{rows: t.rows[start:i]})
+			attach = true
+		}
+		if i < len(t.rows) && t.rows[i].isSubtotal && attach {
+			g := &groups[len(groups)-1]
+			g.trailing = append(g.trailing, t.rows[i])
+		} else {
+			attach = false
+		}
+		start = i + 1
+	}
+	return groups
+}
+
+// sortKeyColumn returns the number, counting from 1, of the column for a
+// sort key.
+func (t *ATable) sortKeyColumn(key SortKey) (int, error) {
+	if key.Name != "" {
+		if t.columnNames == nil {
+			return 0, ErrNoColumnHeaders
+		}
+		columnNumber, ok := t.columnNames[key.Name]
+		if !ok {
+			return 0, ErrorNoSuchColumn(key.Name)
+		}
+		return columnNumber + 1, nil
+	}
+	if key.Column < 1 || key.Column > t.nColumns {
+		return 0, ErrorColumnOutOfRange(key.Column)
+	}
+	return key.Column, nil
+}
+
+type sortColumn struct {
+	SortKey
+	index     int
+	collation collate.Collation
+}
+
+// A sortEntry is a row being sorted, with the values of its cells for each
+// key extracted once, rather than on every comparison, and any rows beneath
+// it which it covers with cells spanning rows, which move with it.
+type sortEntry struct {
+	row     *Row
+	values  []sortValue
+	spanned []*Row
+}
+
+type rowSorter struct {
+	rows    []*Row
+	entries []sortEntry
+	keys    []sortColumn
+}
+
+// newRowSorter extracts the sort values of the rows, for sorting them in
+// place.  Rows covered by a cell spanning rows from above are kept with the
+// entry of the row above, rather than given entries of their own.
+func newRowSorter(rows []*Row, keys []sortColumn) rowSorter {
+	rs := rowSorter{rows: rows, entries: make([]sortEntry, 0, len(rows)), keys: keys}
+	for i, row := range rows {
+		if i > 0 && row.coveredFromAbove() {
+			e := &rs.entries[len(rs.entries)-1]
+			e.spanned = append(e.spanned, row)
+			continue
+		}
+		rs.entries = append(rs.entries, sortEntry{row: row})
+	}
+	values := make([]sortValue, len(rs.entries)*len(keys))
+	for i := range rs.entries {
+		e := &rs.entries[i]
+		row := e.row
+		e.values = values[i*len(keys) : (i+1)*len(keys) : (i+1)*len(keys)]
+		for k := range keys {
+			c := row.sortCell(keys[k].index)
+			if keys[k].Less != nil {
+				// only the nulls policy needs anything extracted
+				e.values[k] = sortValue{empty: c.Empty()}
+				continue
+			}
+			e.values[k] = sortValueOf(c, keys[k].collation != nil)
+		}
+	}
+	return rs
+}
+
+// sort sorts the entries, then puts the rows into their sorted order.
+func (rs rowSorter) sort() {
+	sort.Stable(rs)
+	i := 0
+	for _, e := range rs.entries {
+		rs.rows[i] = e.row
+		i++
+		i += copy(rs.rows[i:], e.spanned)
+	}
+}
+
+func (rs rowSorter) Len() int { return len(rs.entries) }
+func (rs rowSorter) Swap(i, j int) {
+	rs.entries[i], rs.entries[j] = rs.entries[j], rs.entries[i]
+}
+func (rs rowSorter) Less(i, j int) bool {
+	return lessOnKeys(&rs.entries[i], &rs.entries[j], rs.keys)
+}
+
+func lessOnKeys(a, b *sortEntry, keys []sortColumn) bool {
+	for k := range keys {
+		if c := keys[k].compare(a, b, k); c != 0 {
+			return c < 0
+		}
+	}
+	return false
+}
+
+// compare returns a negative number, zero or a positive number as entry a
+// sorts before, with or after entry b on this key, which is key k of the
+// sort.
+func (sc *sortColumn) compare(a, b *sortEntry, k int) int {
+	av, bv := &a.values[k], &b.values[k]
+	if sc.Nulls != NULLS_AS_VALUES {
+		if av.empty != bv.empty {
+			if av.empty == (sc.Nulls == NULLS_FIRST) {
+				return -1
+			}
+			return 1
+		}
+		if av.empty {
+			return 0
+		}
+	}
+	c := 0
+	switch {
+	case sc.Less != nil:
+		ac, bc := a.row.sortCell(sc.index), b.row.sortCell(sc.index)
+		if sc.Less(ac, bc) {
+			c = -1
+		} else if sc.Less(bc, ac) {
+			c = 1
+		}
+	case sc.collation != nil:
+		c = sc.collation.Compare(av.text, bv.text)
+	default:
+		if av.less(bv) {
+			c = -1
+		} else if bv.less(av) {
+			c = 1
+		}
+	}
+	if sc.Order == SORT_DESC {
+		return -c
+	}
+	return c
+}
+
+// sortCell returns the cell of the row at 0-based index i, or an empty cell
+// if the row is too short to have one.
+func (r *Row) sortCell(i int) *Cell {
+	if i < len(r.cells) {
+		return &r.cells[i]
+	}
+	blank := NewCell(nil)
+	return &blank
+}
@@ -0,0 +1,155 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"reflect"
+)
+
+// Sort keys
+//
+// Comparing cells needs reflection on what they hold, which is too slow to
+// repeat on every comparison of a sort.  Instead, a sortValue is extracted
+// once per cell, holding everything which Cell.LessThan might compare, and
+// sorts run on those.  Cell.LessThan itself extracts the two sortValues and
+// compares them, so the ordering is the same either way.
+
+type numericKind int
+
+const (
+	numericNone numericKind = iota
+	numericInt
+	numericUint
+	numericFloat
+)
+
+// sortValue is what a cell is compared by.
+type sortValue struct {
+	empty bool // per Cell.Empty, for the nulls policy of a sort key
+	valid bool // false if the cell holds nothing at all
+
+	isSortInter bool
+	sortInt     int64
+
+	numeric numericKind
+	i       int64
+	u       uint64
+	f       float64
+
+	hasText bool
+	text    string
+}
+
+// innermost returns the cell holding the item at the bottom of any nesting
+// of cells within cells.
+func (c *Cell) innermost() *Cell {
+	for {
+		switch o := c.raw.(type) {
+		case *Cell:
+			c = o
+		case Cell:
+			c = &o
+		default:
+			return c
+		}
+	}
+}
+
+// sortValueOf extracts the sortValue of a cell.  For a column with a
+// collation, only the text is needed, and it is the cell's String form.
+func sortValueOf(c *Cell, collated bool) sortValue {
+	sv := sortValue{empty: c.Empty()}
+	if collated {
+		sv.valid = true
+		sv.hasText = true
+		sv.text = c.String()
+		return sv
+	}
+	raw := c.innermost().raw
+	if f, ok := raw.(*Formula); ok {
+		raw = f.value
+	}
+	v := reflect.ValueOf(raw)
+	if !v.IsValid() {
+		return sv
+	}
+	sv.valid = true
+
+	// SortInter is the first choice, including when defined on types for
+	// which the underlying type is an int.
+	if si, ok := raw.(SortInter); ok {
+		sv.isSortInter = true
+		sv.sortInt = si.SortInt64()
+	}
+
+	// Do not try to convert to uint, because positive floats convert and
+	// lose precision.  Similarly for int.  "Can" is the underlying type.
+	switch {
+	case v.CanInt():
+		sv.numeric, sv.i = numericInt, v.Int()
+	case v.CanUint():
+		sv.numeric, sv.u = numericUint, v.Uint()
+	case v.CanFloat():
+		sv.numeric, sv.f = numericFloat, v.Float()
+	}
+
+	switch x := raw.(type) {
+	case string:
+		sv.hasText, sv.text = true, x
+	case Stringer:
+		sv.hasText, sv.text = true, x.String()
+	case GoStringer:
+		sv.hasText, sv.text = true, x.GoString()
+	}
+	return sv
+}
+
+// float returns the value of a numeric sortValue as a float64.
+func (a *sortValue) float() float64 {
+	switch a.numeric {
+	case numericInt:
+		return float64(a.i)
+	case numericUint:
+		return float64(a.u)
+	default:
+		return a.f
+	}
+}
+
+// less is the heuristic of Cell.LessThan.
+func (a *sortValue) less(b *sortValue) bool {
+	// Nothing at all sorts before everything else.
+	if !a.valid || !b.valid {
+		return !a.valid && b.valid
+	}
+
+	if a.isSortInter {
+		if b.isSortInter {
+			return a.sortInt < b.sortInt
+		} else if b.numeric == numericInt {
+			return a.sortInt < b.i
+		}
+	} else if b.isSortInter {
+		if a.numeric == numericInt {
+			return a.i < b.sortInt
+		}
+	}
+
+	switch {
+	case a.numeric == numericFloat && b.numeric == numericFloat:
+		return a.f < b.f
+	case a.numeric == numericUint && b.numeric == numericUint:
+		return a.u < b.u
+	case a.numeric == numericInt && b.numeric == numericInt:
+		return a.i < b.i
+	case a.numeric != numericNone && b.numeric != numericNone:
+		return a.float() < b.float()
+	}
+
+	if a.hasText && b.hasText {
+		return a.text < b.text
+	}
+	return false
+}
@@ -1,16 +1,20 @@
-// Copyright © 2025 Pennock Tech, LLC.
+// Copyright © 2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
 package tabular_test // import "go.pennock.tech/tabular"
 
 import (
+	"math/rand"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/liquidgecka/testlib"
 
 	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/properties/collate"
 	"go.pennock.tech/tabular/texttable"
 )
 
@@ -239,3 +243,340 @@ func TestSortByColumn(t *testing.T) {
 		T.Log("match on sorting by " + checks[i].Column + " " + checks[i].Order.String())
 	}
 }
+
+func columnStrings(tb tabular.Table, column int) []string {
+	rows := tb.AllRows()
+	r := make([]string, len(rows))
+	for i, row := range rows {
+		r[i] = row.Cells()[column-1].String()
+	}
+	return r
+}
+
+func TestSortByKeys(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Name", "Region", "Cost")
+	tb.AddRowItems("web1", "us-east", 30)
+	tb.AddRowItems("db1", "eu-west", 120)
+	tb.AddRowItems("web2", "us-east", 30)
+	tb.AddRowItems("cache", "us-east", nil)
+	tb.AddRowItems("db2", "us-east", 120)
+	tb.AddRowItems("web3", "eu-west", 30)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	err := tb.SortBy(
+		tabular.SortKey{Name: "Region"},
+		tabular.SortKey{Name: "Cost", Order: tabular.SORT_DESC},
+		tabular.SortKey{Column: 1, Order: tabular.SORT_ASC},
+	)
+	T.ExpectSuccess(err, "sort by three keys")
+	T.Equal(columnStrings(tb, 1), []string{"db1", "web3", "db2", "web1", "web2", "cache"}, "sorted by region, cost desc, name")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Cost"}), "sort by cost")
+	T.Equal(columnStrings(tb, 1), []string{"cache", "web3", "web1", "web2", "db1", "db2"}, "stable sort keeps prior order of equal rows")
+
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Cost", Nulls: tabular.NULLS_LAST}), "sort by cost, nulls last")
+	T.Equal(columnStrings(tb, 1), []string{"web3", "web1", "web2", "db1", "db2", "cache"}, "nulls last")
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Cost", Order: tabular.SORT_DESC, Nulls: tabular.NULLS_LAST}), "sort by cost desc, nulls last")
+	T.Equal(columnStrings(tb, 1), []string{"db1", "db2", "web3", "web1", "web2", "cache"}, "nulls last whatever the order")
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Cost", Order: tabular.SORT_DESC, Nulls: tabular.NULLS_FIRST}), "sort by cost desc, nulls first")
+	T.Equal(columnStrings(tb, 1), []string{"cache", "db1", "db2", "web3", "web1", "web2"}, "nulls first whatever the order")
+
+	byLength := func(a, b *tabular.Cell) bool { return len(a.String()) < len(b.String()) }
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Column: 1, Less: byLength}), "sort with comparator")
+	T.Equal(columnStrings(tb, 1), []string{"db1", "db2", "web3", "web1", "web2", "cache"}, "sorted by comparator")
+
+	T.Equal(tb.SortBy(tabular.SortKey{Name: "Owner"}), tabular.ErrorNoSuchColumn("Owner"), "sort by missing column")
+	T.Equal(tb.SortBy(tabular.SortKey{Column: 4}), tabular.ErrorColumnOutOfRange(4), "sort by column out of range")
+	T.Equal(tb.SortBy(tabular.SortKey{Column: 1, Order: 7}), tabular.ErrUnknownSortOrder, "sort in unknown order")
+}
+
+func TestSortWithinGroups(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Host", "Load")
+	tb.AddRowItems("m", 3)
+	tb.AddRowItems("k", 1)
+	tb.AddRowItems("short")
+	tb.AddSeparator()
+	tb.AddRowItems("c", 9)
+	tb.AddRowItems("a", 2)
+	tb.AddSeparator()
+	tb.AddSeparator()
+	tb.AddRowItems("b", 5)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Load"}), "sort within groups")
+	T.Equal(firstColumn(tb), []string{"short", "k", "m", "--", "a", "c", "--", "--", "b"}, "sorted within groups, short row as empty")
+	T.ExpectSuccess(tb.SortByNamedColumn("Load", tabular.SORT_DESC), "legacy sort within groups")
+	T.Equal(firstColumn(tb), []string{"m", "k", "short", "--", "c", "a", "--", "--", "b"}, "legacy sort keeps separators")
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Load", Nulls: tabular.NULLS_FIRST}), "sort short row first")
+	T.Equal(firstColumn(tb), []string{"short", "k", "m", "--", "a", "c", "--", "--", "b"}, "short row counts as null")
+	checkLocations(T, tb)
+
+	T.ExpectSuccess(tb.SortGroupsBy(tabular.SortKey{Name: "Host"}), "sort groups")
+	T.Equal(firstColumn(tb), []string{"a", "c", "--", "b", "--", "--", "k", "m", "short"}, "groups sorted by first row")
+	T.ExpectSuccess(tb.SortGroupsBy(tabular.SortKey{Name: "Load", Order: tabular.SORT_DESC}), "sort groups descending")
+	T.Equal(firstColumn(tb), []string{"c", "a", "--", "b", "--", "--", "m", "k", "short"}, "groups sorted descending by first row")
+	checkLocations(T, tb)
+}
+
+func TestSortRowSpans(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Service", "Host", "Load")
+	tb.AddRowItems("web", "w2", 7)
+	tb.AddRowItems(tabular.NewSpanningCell("db", 1, 3), "d1", 5)
+	tb.AddRowItems("d2", 1)
+	tb.AddRowItems("d3", 9)
+	tb.AddRowItems("cache", "c1", 2)
+	T.Equal(tb.Errors(), nil, "no errors adding spanning cells")
+
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Load"}), "sort rows joined by a span")
+	T.Equal(columnStrings(tb, 2), []string{"c1", "d1", "d2", "d3", "w2"}, "spanned rows move with their first row")
+	T.Equal(tb.AllRows()[3].Cells()[0].SpanAnchor().String(), "db", "span kept")
+	_, rows := tb.AllRows()[1].Cells()[0].Span()
+	T.Equal(rows, 3, "span still covers its rows")
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Service", Order: tabular.SORT_DESC}), "sort descending by the spanning column")
+	T.Equal(columnStrings(tb, 2), []string{"w2", "d1", "d2", "d3", "c1"}, "spanned rows stay in their order")
+	checkLocations(T, tb)
+}
+
+func TestSortCollation(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Host", "Owner")
+	tb.AddRowItems("host10", "Zoe")
+	tb.AddRowItems("Host3", "émile")
+	tb.AddRowItems("host2", "Émile")
+	tb.AddRowItems("host02", "adam")
+	tb.AddRowItems("host2b", "Zack")
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Host"}), "sort bytewise")
+	T.Equal(columnStrings(tb, 1), []string{"Host3", "host02", "host10", "host2", "host2b"}, "sorted bytewise")
+
+	checks := []struct {
+		Collation collate.Collation
+		Column    int
+		Want      []string
+	}{
+		{collate.Natural, 1, []string{"Host3", "host2", "host02", "host2b", "host10"}},
+		{collate.CaseInsensitive, 1, []string{"host02", "host10", "host2", "host2b", "Host3"}},
+		{collate.NaturalCaseInsensitive, 1, []string{"host2", "host02", "host2b", "Host3", "host10"}},
+		{collate.Bytewise, 2, []string{"Zack", "Zoe", "adam", "Émile", "émile"}},
+	}
+	for i := range checks {
+		tb.Column(checks[i].Column).SetProperty(collate.PropertyType, checks[i].Collation)
+		T.ExpectSuccess(tb.SortBy(tabular.SortKey{Column: checks[i].Column}), "sort with collation")
+		T.Equalf(columnStrings(tb, checks[i].Column), checks[i].Want, "sorted with collation %d", i)
+	}
+
+}
+
+// mixedItems are items of every kind which Cell.LessThan compares, with
+// duplicates, so that stability shows too.  There is no nil, upon which the
+// old Cell.LessThan panicked.
+var mixedItems = []any{
+	7, "beta", 2.5, greek{"gamma", 3}, uint(4), -1, "alpha", int8(9),
+	greek{"alpha", 1}, float32(0.5), "", uint64(4), 7, "beta",
+}
+
+// baselineLessThan is Cell.LessThan as it was before sort keys were extracted
+// once per row, reflecting upon both cells on every comparison; the sorting
+// which replaced it must order cells identically.  It is the old code as it
+// was, but for reading the items of the cells through Item, from outside the
+// package.
+func baselineLessThan(c, d *tabular.Cell) bool {
+	var (
+		g, h, t         *tabular.Cell
+		u               tabular.Cell
+		ok              bool
+		cv, dv          reflect.Value
+		tt, sortIntType reflect.Type
+		as              string
+		af              float64
+		aOkay           bool
+	)
+
+	g, ok = c, true
+	for ok {
+		if t, ok = g.Item().(*tabular.Cell); ok {
+			g = t
+		} else if u, ok = g.Item().(tabular.Cell); ok {
+			g = &u
+		}
+	}
+	cv = reflect.ValueOf(g.Item())
+
+	h, ok = d, true
+	for ok {
+		if t, ok = h.Item().(*tabular.Cell); ok {
+			h = t
+		} else if u, ok = h.Item().(tabular.Cell); ok {
+			h = &u
+		}
+	}
+	dv = reflect.ValueOf(h.Item())
+
+	// Do not try to convert to uint, because positive floats convert and lose precision.
+	// Similarly for int.
+	// Leave _conversions_ for the float.  But "can" is the underlying type.
+	// We want to use SortInter as our _first_ choice, including when defined on types for which the underlying type is an int
+
+	sortIntType = reflect.TypeOf((*tabular.SortInter)(nil)).Elem()
+
+	if cv.Type().Implements(sortIntType) {
+		if dv.Type().Implements(sortIntType) {
+			return cv.Interface().(tabular.SortInter).SortInt64() < dv.Interface().(tabular.SortInter).SortInt64()
+		} else if dv.CanInt() {
+			return cv.Interface().(tabular.SortInter).SortInt64() < dv.Int()
+		}
+	} else if dv.Type().Implements(sortIntType) {
+		if cv.CanInt() {
+			return cv.Int() < dv.Interface().(tabular.SortInter).SortInt64()
+		}
+	}
+
+	if cv.CanFloat() && dv.CanFloat() {
+		return cv.Float() < dv.Float()
+	}
+	if cv.CanUint() && dv.CanUint() {
+		return cv.Uint() < dv.Uint()
+	}
+	if cv.CanInt() {
+		if dv.CanFloat() {
+			return float64(cv.Int()) < dv.Float()
+		} else if dv.CanInt() {
+			return cv.Int() < dv.Int()
+		}
+	} else if cv.CanFloat() && dv.CanInt() {
+		return cv.Float() < float64(dv.Int())
+	}
+
+	tt = reflect.TypeOf(af)
+	if cv.CanConvert(tt) && dv.CanConvert(tt) {
+		return cv.Convert(tt).Float() < dv.Convert(tt).Float()
+	}
+
+	aOkay = false
+	if x, ok := g.Item().(string); ok {
+		as = x
+		aOkay = true
+	} else if x, ok := g.Item().(tabular.Stringer); ok {
+		as = x.String()
+		aOkay = true
+	} else if x, ok := g.Item().(tabular.GoStringer); ok {
+		as = x.GoString()
+		aOkay = true
+	}
+	if aOkay {
+		if x, ok := h.Item().(string); ok {
+			return as < x
+		} else if x, ok := h.Item().(tabular.Stringer); ok {
+			return as < x.String()
+		} else if x, ok := h.Item().(tabular.GoStringer); ok {
+			return as < x.GoString()
+		}
+	}
+
+	return false
+}
+
+func TestSortKeysMatchLessThan(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	for _, order := range []tabular.SortOrder{tabular.SORT_ASC, tabular.SORT_DESC} {
+		extracted := tabular.New()
+		baseline := tabular.New()
+		current := tabular.New()
+		for i, item := range mixedItems {
+			nested := tabular.NewCell(item)
+			extracted.AddRowItems(&nested, i)
+			baseline.AddRowItems(item, i)
+			current.AddRowItems(item, i)
+		}
+		T.ExpectSuccess(extracted.SortBy(tabular.SortKey{Column: 1, Order: order}), "sort on extracted keys")
+		T.ExpectSuccess(baseline.SortBy(tabular.SortKey{Column: 1, Order: order, Less: baselineLessThan}), "sort with the baseline comparator")
+		T.ExpectSuccess(current.SortBy(tabular.SortKey{Column: 1, Order: order, Less: (*tabular.Cell).LessThan}), "sort with LessThan")
+		T.Equal(columnStrings(extracted, 2), columnStrings(baseline, 2), "extracted keys order as the baseline, "+order.String())
+		T.Equal(columnStrings(current, 2), columnStrings(baseline, 2), "LessThan orders as the baseline, "+order.String())
+	}
+
+	extracted := tabular.New()
+	for i, item := range mixedItems {
+		extracted.AddRowItems(item, i)
+	}
+	T.ExpectSuccess(extracted.SortBy(tabular.SortKey{Column: 1}), "sort ascending")
+	T.Equal(columnStrings(extracted, 2), []string{"0", "1", "5", "2", "3", "4", "6", "8", "9", "7", "10", "11", "12", "13"}, "ascending order as sorted by the baseline")
+}
+
+func TestSortNil(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	none, one, text := tabular.NewCell(nil), tabular.NewCell(1), tabular.NewCell("")
+	T.Equal(none.LessThan(&one), true, "nil less than a number")
+	T.Equal(none.LessThan(&text), true, "nil less than a string")
+	T.Equal(one.LessThan(&none), false, "a number not less than nil")
+	T.Equal(none.LessThan(&none), false, "nil not less than itself")
+
+	tb := tabular.New()
+	for i, item := range []any{2, nil, 1, nil} {
+		tb.AddRowItems(item, i)
+	}
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Column: 1}), "sort with nils")
+	T.Equal(columnStrings(tb, 2), []string{"1", "3", "2", "0"}, "nils first, in their order")
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Column: 1, Order: tabular.SORT_DESC}), "sort descending with nils")
+	T.Equal(columnStrings(tb, 2), []string{"0", "2", "1", "3"}, "nils last, in their order")
+}
+
+func benchmarkTable(rows int) tabular.Table {
+	r := rand.New(rand.NewSource(1))
+	tb := tabular.New()
+	tb.AddHeaders("Host", "Load", "Greek")
+	for i := 0; i < rows; i++ {
+		n := r.Int63n(int64(rows))
+		tb.AddRowItems("host"+strconv.FormatInt(n, 10), r.Float64(), greek{"g", n})
+	}
+	return tb
+}
+
+func benchmarkSort(b *testing.B, key tabular.SortKey) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tb := benchmarkTable(20000)
+		b.StartTimer()
+		if err := tb.SortBy(key); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// The Baseline benchmarks sort with baselineLessThan as the comparator, which
+// reflects upon both cells on every comparison as sorting used to, for
+// contrast with the default of extracting each cell's key once.
+
+func BenchmarkSortStrings(b *testing.B) { benchmarkSort(b, tabular.SortKey{Name: "Host"}) }
+func BenchmarkSortStringsBaseline(b *testing.B) {
+	benchmarkSort(b, tabular.SortKey{Name: "Host", Less: baselineLessThan})
+}
+func BenchmarkSortFloats(b *testing.B) { benchmarkSort(b, tabular.SortKey{Name: "Load"}) }
+func BenchmarkSortFloatsBaseline(b *testing.B) {
+	benchmarkSort(b, tabular.SortKey{Name: "Load", Less: baselineLessThan})
+}
+func BenchmarkSortSortInter(b *testing.B) { benchmarkSort(b, tabular.SortKey{Name: "Greek"}) }
+func BenchmarkSortSortInterBaseline(b *testing.B) {
+	benchmarkSort(b, tabular.SortKey{Name: "Greek", Less: baselineLessThan})
+}
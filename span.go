@@ -0,0 +1,261 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+// Spanning cells
+//
+// A cell can be marked as spanning several columns and/or rows of the grid,
+// in the manner of HTML's colspan and rowspan.  The spanning cell is the
+// "anchor" of a merged region, at its top-left.  Every other grid position in
+// the region still holds a Cell, so that column numbering stays consistent
+// for every row, but those cells are empty placeholders which are "covered"
+// by the anchor.
+//
+// When a spanning cell is added to a row, placeholders for the extra columns
+// are appended to the row immediately.  When a row is added to a table
+// beneath a cell which spans rows, placeholders are inserted into the new row
+// at the covered positions and the new row's own cells flow around them, so
+// callers supply cells only for the positions which are not covered, just as
+// in HTML.
+//
+// A separator row ends any row spans which would otherwise cross it.
+
+// cellSpan holds the requested extent of a cell, and the extent in rows
+// actually achieved within a table.
+type cellSpan struct {
+	columns int // as requested; values below 2 mean no column spanning
+	rows    int // as requested; values below 2 mean no row spanning
+	extent  int // rows actually covered so far, maintained by the table
+}
+
+// spanOrigin identifies the anchor covering a placeholder cell.  We hold the
+// row rather than a cell pointer because the row is stable while the slice
+// holding its cells might not be.
+type spanOrigin struct {
+	row    *Row
+	column int // 1-based, as for CellLocation
+}
+
+// NewSpanningCell creates a Cell which covers the given number of columns
+// and rows of the grid, with itself as the top-left.
+func NewSpanningCell(object any, columns, rows int) Cell {
+	c := NewCell(object)
+	c.SetSpan(columns, rows)
+	return c
+}
+
+// SetSpan marks a cell as spanning the given number of columns and rows.
+// This only has an effect if done before the cell is added to a row.
+// Values less than 1 are treated as 1.
+func (c *Cell) SetSpan(columns, rows int) {
+	c.span.columns = max(columns, 1)
+	c.span.rows = max(rows, 1)
+}
+
+// Span returns how many columns and how many rows of the grid a cell covers.
+// This is 1, 1 for an ordinary cell.  Within a table, the rows count is how
+// many rows are actually covered, which can be fewer than requested if the
+// table ends, or a separator is reached, first.  A placeholder cell which is
+// covered by another cell's span returns 0, 0.
+func (c *Cell) Span() (columns, rows int) {
+	if c.spanFrom.row != nil {
+		return 0, 0
+	}
+	columns = max(c.span.columns, 1)
+	rows = max(c.span.rows, 1)
+	if c.inRow != nil && c.inRow.inTable != nil {
+		rows = max(c.span.extent, 1)
+	}
+	return
+}
+
+// SpanCovered is true if the cell is a placeholder lying within the region
+// of another cell which spans over it.
+func (c *Cell) SpanCovered() bool {
+	return c.spanFrom.row != nil
+}
+
+// SpanAnchor returns the cell whose content is shown at this cell's position:
+// for a placeholder which is covered by a spanning cell, that is the spanning
+// cell, otherwise it is the cell itself.
+func (c *Cell) SpanAnchor() *Cell {
+	o := c.spanFrom
+	if o.row == nil || o.column < 1 || o.column > len(o.row.cells) {
+		return c
+	}
+	return &o.row.cells[o.column-1]
+}
+
+// newPlaceholderCell returns an empty cell covered by the anchor at the given
+// position.
+func newPlaceholderCell(anchorRow *Row, anchorColumn int) Cell {
+	return Cell{empty: true, spanFrom: spanOrigin{row: anchorRow, column: anchorColumn}}
+}
+
+// spanningRows reports whether a cell is an anchor asking for more than one
+// row.
+func (c *Cell) spanningRows() bool {
+	return c.spanFrom.row == nil && c.span.rows > 1
+}
+
+// coveredFromAbove reports whether a row of a table has a position covered by
+// a cell spanning rows from a row above it.
+func (r *Row) coveredFromAbove() bool {
+	for i := range r.cells {
+		if o := r.cells[i].spanFrom.row; o != nil && o != r {
+			return true
+		}
+	}
+	return false
+}
+
+// spansBelow reports whether a row of a table holds a cell which covers
+// positions in the rows beneath it.
+func (r *Row) spansBelow() bool {
+	for i := range r.cells {
+		if r.cells[i].spanFrom.row == nil && r.cells[i].span.extent > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// rowSpansInto returns the columns (1-based) of a prospective row, at the
+// given row number, which are covered by cells above which span rows.
+// Only the immediately preceding row needs to be examined, since a span
+// covering this row must also cover that one.
+func (t *ATable) rowSpansInto(rowNum int) map[int]spanOrigin {
+	if rowNum < 2 || rowNum-1 > len(t.rows) {
+		return nil
+	}
+	prev := t.rows[rowNum-2]
+	if prev.isSeparator {
+		return nil
+	}
+	var covered map[int]spanOrigin
+	for i := range prev.cells {
+		anchor := prev.cells[i].SpanAnchor()
+		if !anchor.spanningRows() || anchor.inRow == nil {
+			continue
+		}
+		if anchor.inRow.rowNum+anchor.span.rows-1 < rowNum {
+			continue
+		}
+		if covered == nil {
+			covered = make(map[int]spanOrigin, 4)
+		}
+		covered[i+1] = spanOrigin{row: anchor.inRow, column: anchor.columnNum}
+	}
+	return covered
+}
+
+// flowAroundRowSpans inserts placeholders into a row which is about to be
+// placed at position rowNum, so that the row's own cells avoid the columns
+// covered by cells above which span rows.
+func (t *ATable) flowAroundRowSpans(row *Row, rowNum int) {
+	if row.cells == nil {
+		return
+	}
+	covered := t.rowSpansInto(rowNum)
+	if covered == nil {
+		return
+	}
+	lastCovered := 0
+	for col := range covered {
+		lastCovered = max(lastCovered, col)
+	}
+	cells := make([]Cell, 0, len(row.cells)+len(covered))
+	next := 0
+	for col := 1; next < len(row.cells) || col <= lastCovered; col++ {
+		if origin, ok := covered[col]; ok {
+			cells = append(cells, newPlaceholderCell(origin.row, origin.column))
+			continue
+		}
+		if next < len(row.cells) {
+			cells = append(cells, row.cells[next])
+			next++
+		} else {
+			// pad a short row out to reach the covered columns
+			cells = append(cells, NewCell(nil))
+		}
+	}
+	for i := range cells {
+		cells[i].inRow = row
+		cells[i].columnNum = i + 1
+	}
+	row.cells = cells
+}
+
+// markRowSpans updates the span bookkeeping for the row at the given index
+// of t.rows, assuming that all rows above it are already correct.
+func (t *ATable) markRowSpans(index int) {
+	row := t.rows[index]
+	if row.cells == nil {
+		return
+	}
+	for i := range row.cells {
+		row.cells[i].spanFrom = spanOrigin{}
+	}
+	for col, origin := range t.rowSpansInto(index + 1) {
+		if col > len(row.cells) {
+			continue
+		}
+		row.cells[col-1].spanFrom = origin
+		anchor := &origin.row.cells[origin.column-1]
+		anchor.span.extent = index + 2 - origin.row.rowNum
+	}
+	markColumnSpans(row)
+}
+
+// markColumnSpans handles spans within a single row: anchors covering
+// columns to their right, including anchors from rows above, whose region
+// is a rectangle.
+func markColumnSpans(row *Row) {
+	for i := 0; i < len(row.cells); i++ {
+		c := &row.cells[i]
+		var origin spanOrigin
+		columns := 1
+		if c.spanFrom.row != nil {
+			anchor := c.SpanAnchor()
+			if anchor.columnNum != i+1 {
+				continue
+			}
+			origin = c.spanFrom
+			columns = anchor.span.columns
+		} else {
+			c.span.extent = 1
+			origin = spanOrigin{row: row, column: i + 1}
+			columns = c.span.columns
+		}
+		for j := 1; j < columns && i+j < len(row.cells); j++ {
+			if row.cells[i+j].spanFrom.row == nil {
+				row.cells[i+j].spanFrom = origin
+			}
+		}
+	}
+}
+
+// recomputeSpans rebuilds all span bookkeeping for the table, and marks the
+// layout as changed; it should be called after any operation which
+// restructures rows or columns.
+func (t *ATable) recomputeSpans() {
+	t.layout++
+	outer := make([]*Row, 0, len(t.headerGroupRows)+len(t.footerRows)+1)
+	outer = append(outer, t.headerGroupRows...)
+	outer = append(outer, t.footerRows...)
+	if t.headerRow != nil {
+		outer = append(outer, t.headerRow)
+	}
+	for _, row := range outer {
+		for i := range row.cells {
+			row.cells[i].spanFrom = spanOrigin{}
+		}
+		markColumnSpans(row)
+	}
+	for i := range t.rows {
+		t.rows[i].rowNum = i + 1
+		t.markRowSpans(i)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+)
+
+func TestSpanningCells(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("name", "min", "max", "unit")
+	tb.AddRowItems(tabular.NewSpanningCell("latency", 1, 2), 3, 9, "ms")
+	tb.AddRowItems(4, 12, "ms")
+	tb.AddRowItems("errors", tabular.NewSpanningCell("none", 2, 1), "count")
+	tb.AddRowItems(tabular.NewSpanningCell("cut", 1, 3), 1, 2, 3)
+	tb.AddSeparator()
+	tb.AddRowItems("a", "b", "c", "d")
+	T.Equal(tb.Errors(), nil, "no errors adding spanning cells")
+
+	T.Equal(tb.NColumns(), 4, "spans don't create extra columns")
+	T.Equal(tb.NRows(), 6, "table should have 6 rows in the body")
+
+	for _, row := range tb.AllRows() {
+		if row.IsSeparator() {
+			continue
+		}
+		T.Equalf(len(row.Cells()), 4, "row %d should have a cell for every column", row.Location().Row)
+	}
+
+	for _, status := range []struct {
+		r, c       int
+		want       string
+		covered    bool
+		cols, rows int
+	}{
+		{1, 1, "latency", false, 1, 2},
+		{2, 1, "latency", true, 0, 0},
+		{2, 2, "4", false, 1, 1},
+		{2, 4, "ms", false, 1, 1},
+		{3, 2, "none", false, 2, 1},
+		{3, 3, "none", true, 0, 0},
+		{3, 4, "count", false, 1, 1},
+		{4, 1, "cut", false, 1, 1},
+		{6, 1, "a", false, 1, 1},
+	} {
+		loc := tabular.CellLocation{Row: status.r, Column: status.c}
+		c, err := tb.CellAt(loc)
+		T.ExpectSuccessf(err, "cell [%d,%d] should have been available", status.r, status.c)
+		if c == nil {
+			continue
+		}
+		T.Equalf(c.String(), status.want, "cell [%d,%d] content", status.r, status.c)
+		raw := &tb.AllRows()[status.r-1].Cells()[status.c-1]
+		T.Equalf(raw.SpanCovered(), status.covered, "cell [%d,%d] covered", status.r, status.c)
+		cols, rows := raw.Span()
+		T.Equalf(cols, status.cols, "cell [%d,%d] columns spanned", status.r, status.c)
+		T.Equalf(rows, status.rows, "cell [%d,%d] rows spanned", status.r, status.c)
+	}
+
+	tb.AddHeaders(tabular.NewSpanningCell("range", 3, 1), "unit")
+	T.Equal(len(tb.Headers()), 4, "spanning header still provides a cell per column")
+	T.Equal(tb.Headers()[1].SpanAnchor().String(), "range", "covered header shows its anchor")
+	col, err := tb.ColumnNamed("unit")
+	T.ExpectSuccess(err, "header after a spanning header names a column")
+	T.Equal(col, tb.Column(4), "column numbering after a spanning header is consistent")
+
+	plain := tabular.NewCell("plain")
+	tb.AddRowItems(plain)
+	last := tb.AllRows()[tb.NRows()-1].Cells()[0]
+	T.Equal(last.Item(), any(plain), "a cell which does not span is held by a new cell")
+}
@@ -0,0 +1,223 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.pennock.tech/tabular/properties"
+	"go.pennock.tech/tabular/properties/align"
+)
+
+// Structs
+//
+// A slice of structs can be added to a table with AddStructs, one row per
+// struct and one column per exported field, flattening embedded structs as
+// encoding/json does.  A field's column is named for the field, unless its
+// struct tag says otherwise; the tag's key is "tabular", and its value is the
+// column name, optionally followed by comma-separated options:
+//
+//	align=left, align=right or align=center
+//	    sets align.PropertyType on the column
+//	omit
+//	    sets properties.Omit on the column, so that it is not rendered
+//	format=...
+//	    shows the field as formatted by fmt.Sprintf with this format; it takes
+//	    the rest of the tag, so may contain commas
+//
+// A name of "-" skips the field, and an empty name is the field's own name,
+// so `tabular:",align=right"` keeps the name but sets the alignment.
+
+// ErrNotStructs is recorded by AddStructs when given something other than a
+// slice or array of structs, or of pointers to structs.
+var ErrNotStructs = errors.New("not a slice of structs")
+
+// ErrorStructTag is recorded for a tabular struct tag which is not understood.
+type ErrorStructTag struct {
+	Field  string
+	Option string
+}
+
+func (e ErrorStructTag) Error() string {
+	return fmt.Sprintf("unknown tabular struct tag option %q on field %s", e.Option, e.Field)
+}
+
+// A structField is an exported field of a struct, with its tabular tag
+// parsed.
+type structField struct {
+	name   string
+	index  []int
+	align  align.Alignment
+	omit   bool
+	format string
+}
+
+// structFields returns the fields of a struct type which become columns.
+func structFields(st reflect.Type) ([]structField, error) {
+	var fields []structField
+	for _, f := range reflect.VisibleFields(st) {
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// promoted fields are visited in their own right
+				continue
+			}
+		}
+		sf := structField{name: f.Name, index: f.Index}
+		tag, ok := f.Tag.Lookup("tabular")
+		if !ok {
+			fields = append(fields, sf)
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if name != "" {
+			sf.name = name
+		}
+		for options != "" {
+			var option string
+			if strings.HasPrefix(options, "format=") {
+				option, options = options, ""
+			} else {
+				option, options, _ = strings.Cut(options, ",")
+			}
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "align":
+				switch value {
+				case "left":
+					sf.align = align.Left
+				case "right":
+					sf.align = align.Right
+				case "center", "centre":
+					sf.align = align.Center
+				default:
+					return nil, ErrorStructTag{Field: f.Name, Option: option}
+				}
+			case "omit":
+				sf.omit = true
+			case "format":
+				sf.format = value
+			default:
+				return nil, ErrorStructTag{Field: f.Name, Option: option}
+			}
+		}
+		fields = append(fields, sf)
+	}
+	return fields, nil
+}
+
+// cell returns the cell for the field of a struct value; a field within a
+// nil embedded pointer is empty.
+func (sf *structField) cell(v reflect.Value) Cell {
+	fv, err := v.FieldByIndexErr(sf.index)
+	if err != nil {
+		return NewCell(nil)
+	}
+	if sf.format != "" {
+		return formattedCell(fv.Interface(), sf.format)
+	}
+	return cellForItem(fv.Interface())
+}
+
+// formattedCell returns a cell showing an item as formatted by fmt.Sprintf,
+// but which holds, sorts and aggregates by the item itself.
+func formattedCell(item any, format string) Cell {
+	c := Cell{raw: item, format: format}
+	c.Update()
+	return c
+}
+
+// AddStructs adds a row for each struct in a slice or array of structs, or
+// of pointers to structs; nil pointers are skipped.  If the table has no
+// headers yet, then the headers are made from the fields, else each field is
+// put in the column with its name, and a field without one is an error.  The
+// options of the struct tags are applied to the columns.  Any errors
+// accumulate in the table, which is returned.
+func (t *ATable) AddStructs(slice any) Table {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		t.AddError(ErrNotStructs)
+		return t
+	}
+	st := v.Type().Elem()
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		t.AddError(ErrNotStructs)
+		return t
+	}
+	fields, err := structFields(st)
+	if err != nil {
+		t.AddError(err)
+		return t
+	}
+
+	if t.headerRow == nil {
+		names := make([]any, len(fields))
+		for i := range fields {
+			names[i] = fields[i].name
+		}
+		t.AddHeaders(names...)
+	}
+	columns := make([]int, 0, len(fields))
+	placed := make([]structField, 0, len(fields))
+	for i := range fields {
+		n, err := columnNumberNamed(t, fields[i].name)
+		if err != nil {
+			t.AddError(err)
+			continue
+		}
+		columns = append(columns, n)
+		placed = append(placed, fields[i])
+		if fields[i].align != nil {
+			t.columns[n].SetProperty(align.PropertyType, fields[i].align)
+		}
+		if fields[i].omit {
+			t.columns[n].SetProperty(properties.Omit, true)
+		}
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		sv := v.Index(i)
+		if sv.Kind() == reflect.Pointer {
+			if sv.IsNil() {
+				continue
+			}
+			sv = sv.Elem()
+		}
+		cells := make([]Cell, t.nColumns)
+		for j := range cells {
+			cells[j] = NewCell(nil)
+		}
+		for j := range placed {
+			cells[columns[j]-1] = placed[j].cell(sv)
+		}
+		row := NewRowWithCapacity(len(cells))
+		for j := range cells {
+			row.Add(cells[j])
+		}
+		t.AddRow(row)
+	}
+	return t
+}
+
+// AddStructsOf is the generic counterpart of AddStructs, adding a row to the
+// table for each struct, or pointer to a struct, in a typed slice.
+func AddStructsOf[S any](t Table, items []S) Table {
+	return t.AddStructs(items)
+}
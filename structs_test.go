@@ -0,0 +1,82 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/properties"
+	"go.pennock.tech/tabular/properties/align"
+	"go.pennock.tech/tabular/texttable"
+)
+
+type hostBase struct {
+	Site string
+}
+
+type host struct {
+	Name     string
+	internal int
+	*hostBase
+	Disk   float64 `tabular:"Disk GB,align=right,format=%.1f"`
+	Serial string  `tabular:",omit"`
+	Notes  string  `tabular:"-"`
+	Cores  int     `tabular:",align=right"`
+}
+
+func TestAddStructs(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	hosts := []host{
+		{Name: "web1", hostBase: &hostBase{Site: "lon"}, Disk: 250, Serial: "A1", Cores: 8},
+		{Name: "db1", hostBase: &hostBase{Site: "nyc"}, Disk: 1000.25, Serial: "B2", Cores: 32},
+		{Name: "spare", Disk: 40, Serial: "C3"},
+	}
+	tb := texttable.New()
+	_, err := tb.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	tabular.AddStructsOf(tb, hosts)
+	T.Equal(headerNames(tb), []string{"Name", "Site", "Disk GB", "Serial", "Cores"}, "headers from fields and tags")
+	T.Equal(tb.Column(3).GetProperty(align.PropertyType), align.Right, "alignment from tag")
+	T.Equal(tb.Column(4).GetProperty(properties.Omit), true, "omit from tag")
+	T.Equal(tb.AllRows()[1].Cells()[2].String(), "1000.2", "formatted")
+	T.Equal(tb.AllRows()[1].Cells()[2].Empty(), false, "formatted cell not empty")
+
+	tb.AddStructs([]*host{{Name: "mail1", hostBase: &hostBase{Site: "lon"}, Disk: 9.5, Cores: 2}, nil})
+	T.Equal(tb.NRows(), 4, "nil pointers skipped")
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+	T.ExpectSuccess(tb.SortBy(tabular.SortKey{Name: "Disk GB", Order: tabular.SORT_DESC}), "sort formatted column")
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "structs rendered")
+	T.Equal(rendered, strings.TrimLeft(`
++-------+------+---------+-------+
+| Name  | Site | Disk GB | Cores |
++-------+------+---------+-------+
+| db1   | nyc  |  1000.2 |    32 |
+| web1  | lon  |   250.0 |     8 |
+| spare |      |    40.0 |     0 |
+| mail1 | lon  |     9.5 |     2 |
++-------+------+---------+-------+
+| Total |      | 1299.75 |       |
++-------+------+---------+-------+
+`, "\n"), "structs rendered, sorted and totalled by value")
+
+	tb.AddStructs([]struct{ Name, Owner string }{{"x", "y"}})
+	T.Equal(tb.Errors(), []error{tabular.ErrorNoSuchColumn("Owner")}, "field without a column")
+	T.Equal(columnStrings(tb, 1)[4], "x", "fields with columns still added")
+
+	bad := tabular.New()
+	bad.AddStructs([]string{"no"})
+	bad.AddStructs([]struct {
+		A int `tabular:",bold"`
+	}{{1}})
+	T.Equal(bad.Errors(), []error{tabular.ErrNotStructs, tabular.ErrorStructTag{Field: "A", Option: "bold"}}, "bad input recorded")
+}
@@ -1,4 +1,4 @@
-// Copyright © 2016,2025 Pennock Tech, LLC.
+// Copyright © 2016,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -17,15 +17,50 @@ type Table interface {
 	NRows() int
 	Headers() []Cell
 	AddHeaders(items ...any) Table
+	AddHeaderGroups(groups ...HeaderGroup) Table
+	HeaderGroupRows() []*Row
+	SetTitle(string) Table
+	Title() string
+	SetSubtitle(string) Table
+	Subtitle() string
+	AddFooterRow(row *Row) Table
+	AddFooterItems(items ...any) Table
+	FooterRows() []*Row
+	TotalsRow() *Row
+	SetTotalsLabel(any) Table
 	AllRows() []*Row
 	NewRowSizedFor() *Row
 	AppendNewRow() *Row
 	AddRowItems(items ...any) Table
+	AddStructs(slice any) Table
+	AddMaps(maps []map[string]any, order KeyOrder) Table
+	SetSchema(schema Schema) Table
+	AddComputedColumn(name string, fn func(*Row) any) Table
+	InsertRowAt(position int, row *Row) error
+	RemoveRow(position int) (*Row, error)
+	MoveRow(from, to int) error
+	ReplaceRow(position int, row *Row) (*Row, error)
+	Truncate(count int) error
 	CellAt(location CellLocation) (*Cell, error)
+	SetCellAt(loc CellLocation, item any) error
+	Recalculate()
 	Column(int) *Column
 	ColumnNamed(string) (*Column, error)
+	InsertColumn(column int, header any, items ...any) error
+	DeleteColumn(column int) error
+	MoveColumn(from, to int) error
+	RenameColumn(oldName, newName string) error
 	SortByNamedColumn(string, SortOrder) error
 	SortByColumnNumber(int, SortOrder) error
+	SortBy(keys ...SortKey) error
+	SortGroupsBy(keys ...SortKey) error
+	GroupBy(key SortKey, totals ...GroupTotal) error
+	Filter(keep func(*Row) bool) Table
+	OmitUnless(keep func(*Row) bool) Table
+	Project(names ...string) (Table, error)
+	Transpose() Table
+	Clone(callbacks bool) Table
+	Pivot(rowKey, columnKey, value string, agg Aggregator) (Table, error)
 
 	RegisterPropertyCallback(PropertyOwner, callbackTime, cbTarget, PropertyCallback) error
 	InvokeRenderCallbacks()
@@ -1,4 +1,4 @@
-// Copyright © 2016 Pennock Tech, LLC.
+// Copyright © 2016,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -35,6 +35,32 @@ type Decoration struct {
 		┃ C ┃ Name  ┃ N ┃
 		┣━━━╇━━━━━━━╇━━━┫
 		┗━━━┷━━━━━━━┷━━━┛
+
+		Cells spanning columns need junctions with a line on one side only:
+		┏━━━━━━━━━━━┳━━━┓   +-----------+---+  TopLeft HOuter HOuter HOuter HTopDown HOuter TopRight
+		┃ Spanning  ┃ N ┃   |           |   |
+		┣━━━┯━━━━━━━╇━━━┫   +---+-------+---+  HBLeft HOuter BTopDown HOuter HBCross HOuter HBRight
+		┃ a │ Funky │ 1 ┃   |   |       |   |
+		┠───┴───────┼───┨   +---+-------+---+  LeftBodyRule HRule RuleUp HRule CrossPiece HRule RightBodyRule
+		┃ Wide      │ 2 ┃   |           |   |
+		┠───┬───────┼───┨   +---+-------+---+  LeftBodyRule HRule RuleDown HRule CrossPiece HRule RightBodyRule
+		┃ b │ Hello │ 3 ┃   |   |       |   |
+		┗━━━┷━━━━━━━┷━━━┛   +---+-------+---+
+		and a header cell above an unspanned column, over a spanning body cell,
+		uses HBUp.
+
+		Header groups above the column headers:
+		┏━━━━━━━━━━━┳━━━┓   +-----------+---+  TopLeft HOuter HOuter HOuter HTopDown HOuter TopRight
+		┃ Group     ┃   ┃   |           |   |
+		┣━━━┳━━━━━━━╋━━━┫   +---+-------+---+  HBLeft HOuter HTopDown HOuter HHCross HOuter HBRight
+		┃ C ┃ Name  ┃ N ┃   |   |       |   |
+		┣━━━╇━━━━━━━╇━━━┫   +---+-------+---+
+
+		Footer rows beneath the body:
+		┃ c │ Final │ 3 ┃   |   |       |   |
+		┣━━━┿━━━━━━━┿━━━┫   +---+-------+---+  HBLeft HOuter BFCross HOuter BFCross HOuter HBRight
+		┃   │ Total │ 6 ┃   |   |       |   |
+		┗━━━┷━━━━━━━┷━━━┛   +---+-------+---+
 	*/
 	Horizontal string // unused-for-render
 	Vertical   string // unused-for-render
@@ -60,6 +86,11 @@ type Decoration struct {
 	HBCross       string
 	HBLeft        string
 	HBRight       string
+	HBUp          string
+	RuleUp        string
+	RuleDown      string
+	HHCross       string
+	BFCross       string
 
 	// might change this to non-bool, if we want to control options such as blank line
 	// between headers and content, etc.
@@ -103,6 +134,11 @@ func (d *Decoration) Populate() {
 	decorateDefaultTo(d, "HBCross", "CrossPiece")
 	decorateDefaultTo(d, "HBLeft", "LeftBodyRule")
 	decorateDefaultTo(d, "HBRight", "RightBodyRule")
+	decorateDefaultTo(d, "HBUp", "BBottomUp")
+	decorateDefaultTo(d, "RuleUp", "BBottomUp")
+	decorateDefaultTo(d, "RuleDown", "TopDown")
+	decorateDefaultTo(d, "HHCross", "CrossPiece")
+	decorateDefaultTo(d, "BFCross", "HBCross")
 }
 
 func decorateDefaultTo(d *Decoration, toFill, src string) {
@@ -1,4 +1,4 @@
-// Copyright © 2016,2018,2025 Pennock Tech, LLC.
+// Copyright © 2016,2018,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -74,18 +74,85 @@ func (e *emitter) SetNoResetEOL(onoff bool) {
 	e.noResetEOL = onoff
 }
 
+// junctions holds the choices for where a horizontal line meets a vertical
+// column divider, depending upon which sides of the line have a divider.
+type junctions struct {
+	cross string // divider both above and below
+	up    string // divider above only
+	down  string // divider below only
+	none  string // no divider either side, because of spanning cells
+}
+
 func (e emitter) commonTemplateLine(left, horiz, cross, right string) string {
+	return e.commonJunctionLine(left, horiz, right, junctions{cross, cross, cross, cross}, nil, nil)
+}
+
+// dividedAfter reports whether there is a divider between column i and the
+// next displayed column after it, given a row's spans; see
+// BodyLineRenderedSpanned for the meaning of spans.  A nil spans means no
+// spanning, so every column is divided from the next.
+func dividedAfter(spans []int, i, next int) bool {
+	if spans == nil {
+		return true
+	}
+	start := 0
+	for c := 0; c <= i && c < len(spans); c++ {
+		if spans[c] >= 1 {
+			start = c
+		}
+	}
+	return start+max(spans[start], 1) <= next
+}
+
+// commonJunctionLine builds a horizontal line, picking each junction based
+// upon whether the rows above and below have a divider at that point.
+func (e emitter) commonJunctionLine(left, horiz, right string, js junctions, above, below []int) string {
+	return e.commonOverlaidLine(left, horiz, right, js, above, below, WidthString{})
+}
+
+// commonOverlaidLine is commonJunctionLine with some text overlaid on the
+// line, near the start; the caller is responsible for checking that the text
+// fits, per TextFitsInLine.
+func (e emitter) commonOverlaidLine(left, horiz, right string, js junctions, above, below []int, text WidthString) string {
 	if e.decor.isBoxless {
 		return ""
 	}
 	fields := make([]string, 0, len(e.colWidths)*2+4)
 	fields = append(fields, e.escStart)
 	fields = append(fields, left)
+	// how much of the line is still to be covered by the text
+	overlaid := 0
+	if text.W > 0 {
+		fields = append(fields, horiz+" "+text.S+" ")
+		overlaid = text.W + 3
+	}
 	if len(e.colWidths) > 0 {
 		for i := range e.colWidths {
-			if e.colWidths[i] >= 0 {
-				fields = append(fields, strings.Repeat(horiz, 2+e.colWidths[i]))
-				fields = append(fields, cross)
+			if e.colWidths[i] < 0 {
+				continue
+			}
+			run := 2 + e.colWidths[i]
+			if overlaid >= run+1 {
+				// this run and the junction after it are both covered
+				overlaid -= run + 1
+				continue
+			}
+			fields = append(fields, strings.Repeat(horiz, run-overlaid))
+			overlaid = 0
+			next := i + 1
+			for next < len(e.colWidths) && e.colWidths[next] < 0 {
+				next++
+			}
+			up, down := dividedAfter(above, i, next), dividedAfter(below, i, next)
+			switch {
+			case up && down:
+				fields = append(fields, js.cross)
+			case up:
+				fields = append(fields, js.up)
+			case down:
+				fields = append(fields, js.down)
+			default:
+				fields = append(fields, js.none)
 			}
 		}
 		fields[len(fields)-1] = right
@@ -104,24 +171,118 @@ func (e emitter) commonTemplateLine(left, horiz, cross, right string) string {
 	return strings.Join(fields, "")
 }
 
-func (e emitter) LineHeaderTop() string {
-	return e.commonTemplateLine(e.decor.TopLeft, e.decor.HOuter, e.decor.HTopDown, e.decor.TopRight)
+func (e emitter) LineHeaderTop() string { return e.LineHeaderTopSpanned(nil) }
+
+func (e emitter) LineHeaderBodySep() string { return e.LineHeaderBodySepSpanned(nil, nil) }
+
+func (e emitter) LineBodyFooterSep() string { return e.LineBodyFooterSepSpanned(nil, nil) }
+
+func (e emitter) LineBodyTop() string { return e.LineBodyTopSpanned(nil) }
+
+func (e emitter) LineBottom() string { return e.LineBottomSpanned(nil) }
+
+func (e emitter) LineSeparator() string { return e.LineSeparatorSpanned(nil, nil) }
+
+// TableWidth returns the width of each line of the table, in terminal cells,
+// assuming that the decoration uses characters one cell wide.
+func (e emitter) TableWidth() int {
+	shown, width := 0, 0
+	for _, w := range e.colWidths {
+		if w >= 0 {
+			shown++
+			width += w
+		}
+	}
+	if e.decor.isBoxless {
+		return width + max(shown-1, 0)
+	}
+	if shown == 0 {
+		return 2
+	}
+	// left edge, then for each column: space, content, space, divider
+	return 1 + width + 3*shown
+}
+
+// TextFitsInLine reports whether text can be overlaid onto the horizontal
+// lines of the table, keeping at least one line character at each end.
+// Empty text, or text with a negative width, never fits.
+func (e emitter) TextFitsInLine(text WidthString) bool {
+	if e.decor.isBoxless || text.W <= 0 {
+		return false
+	}
+	// corner, line, space, text, space, ..., line, corner
+	return text.W+6 <= e.TableWidth()
+}
+
+// LineHeaderTopTitled is LineHeaderTopSpanned with a title overlaid.
+func (e emitter) LineHeaderTopTitled(below []int, title WidthString) string {
+	d := e.decor
+	return e.commonOverlaidLine(d.TopLeft, d.HOuter, d.TopRight,
+		junctions{d.HTopDown, d.HOuter, d.HTopDown, d.HOuter}, nil, below, title)
+}
+
+// LineBodyTopTitled is LineBodyTopSpanned with a title overlaid.
+func (e emitter) LineBodyTopTitled(below []int, title WidthString) string {
+	d := e.decor
+	return e.commonOverlaidLine(d.TopLeft, d.HOuter, d.TopRight,
+		junctions{d.BTopDown, d.HOuter, d.BTopDown, d.HOuter}, nil, below, title)
+}
+
+// LineBottomTitled is LineBottomSpanned with a subtitle overlaid.
+func (e emitter) LineBottomTitled(above []int, subtitle WidthString) string {
+	d := e.decor
+	return e.commonOverlaidLine(d.BottomLeft, d.HOuter, d.BottomRight,
+		junctions{d.BBottomUp, d.BBottomUp, d.HOuter, d.HOuter}, above, nil, subtitle)
+}
+
+// The *Spanned line variants take the spans of the rows adjacent to the line,
+// as passed to BodyLineRenderedSpanned, so that junctions are only drawn
+// where a column divider meets the line.
+
+func (e emitter) LineHeaderTopSpanned(below []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.TopLeft, d.HOuter, d.TopRight,
+		junctions{d.HTopDown, d.HOuter, d.HTopDown, d.HOuter}, nil, below)
+}
+
+func (e emitter) LineHeaderBodySepSpanned(above, below []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.HBLeft, d.HOuter, d.HBRight,
+		junctions{d.HBCross, d.HBUp, d.BTopDown, d.HOuter}, above, below)
+}
+
+// LineHeaderGroupSepSpanned is the line between a row of header groups and
+// the header row beneath it, which might be another row of groups.
+func (e emitter) LineHeaderGroupSepSpanned(above, below []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.HBLeft, d.HOuter, d.HBRight,
+		junctions{d.HHCross, d.HBUp, d.HTopDown, d.HOuter}, above, below)
 }
 
-func (e emitter) LineHeaderBodySep() string {
-	return e.commonTemplateLine(e.decor.HBLeft, e.decor.HOuter, e.decor.HBCross, e.decor.HBRight)
+// LineBodyFooterSepSpanned is the line between the body and the rows of the
+// footer; it is drawn like the header rule, between body dividers.
+func (e emitter) LineBodyFooterSepSpanned(above, below []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.HBLeft, d.HOuter, d.HBRight,
+		junctions{d.BFCross, d.BBottomUp, d.BTopDown, d.HOuter}, above, below)
 }
 
-func (e emitter) LineBodyTop() string {
-	return e.commonTemplateLine(e.decor.TopLeft, e.decor.HOuter, e.decor.BTopDown, e.decor.TopRight)
+func (e emitter) LineBodyTopSpanned(below []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.TopLeft, d.HOuter, d.TopRight,
+		junctions{d.BTopDown, d.HOuter, d.BTopDown, d.HOuter}, nil, below)
 }
 
-func (e emitter) LineBottom() string {
-	return e.commonTemplateLine(e.decor.BottomLeft, e.decor.HOuter, e.decor.BBottomUp, e.decor.BottomRight)
+func (e emitter) LineBottomSpanned(above []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.BottomLeft, d.HOuter, d.BottomRight,
+		junctions{d.BBottomUp, d.BBottomUp, d.HOuter, d.HOuter}, above, nil)
 }
 
-func (e emitter) LineSeparator() string {
-	return e.commonTemplateLine(e.decor.LeftBodyRule, e.decor.HRule, e.decor.CrossPiece, e.decor.RightBodyRule)
+func (e emitter) LineSeparatorSpanned(above, below []int) string {
+	d := e.decor
+	return e.commonJunctionLine(d.LeftBodyRule, d.HRule, d.RightBodyRule,
+		junctions{d.CrossPiece, d.RuleUp, d.RuleDown, d.HRule}, above, below)
 }
 
 func (e emitter) LineHeaderBlanks() string {
@@ -148,7 +309,34 @@ func (e emitter) BodyDividers() DividerSet {
 	}
 }
 
-func (e emitter) commonRenderedLine(ds DividerSet, cellStrs []WidthString, colAligns []align.Alignment) string {
+// SpannedWidth returns the width available for content in a field covering
+// count columns starting from column first, including the space which would
+// otherwise be taken by the dividers between them.  Omitted columns
+// contribute nothing; if all are omitted, the result is -1.
+func (e emitter) SpannedWidth(first, count int, ds DividerSet) int {
+	gap := 1
+	if ds.Inner != "" {
+		// space, divider, space
+		gap = 3
+	}
+	width, shown := 0, 0
+	for i := first; i < first+count && i < len(e.colWidths); i++ {
+		if e.colWidths[i] < 0 {
+			continue
+		}
+		if shown > 0 {
+			width += gap
+		}
+		width += e.colWidths[i]
+		shown++
+	}
+	if shown == 0 {
+		return -1
+	}
+	return width
+}
+
+func (e emitter) commonRenderedLine(ds DividerSet, cellStrs []WidthString, colAligns []align.Alignment, spans []int) string {
 	fields := make([]string, 0, len(e.colWidths)*2+1)
 	eolReset := e.escStop
 	if e.noResetEOL {
@@ -157,13 +345,23 @@ func (e emitter) commonRenderedLine(ds DividerSet, cellStrs []WidthString, colAl
 	if ds.Left != "" {
 		fields = append(fields, e.escStart+ds.Left+e.escCellStart)
 	}
-	for i := range e.colWidths {
-		if e.colWidths[i] >= 0 {
-			fields = append(fields, cellStrs[i].WithinWidthAligned(e.colWidths[i], colAligns[i]))
+	for i := 0; i < len(e.colWidths); {
+		count := 1
+		if spans != nil && i < len(spans) {
+			if spans[i] == 0 {
+				// covered by a field to the left, shouldn't happen
+				i++
+				continue
+			}
+			count = spans[i]
+		}
+		if width := e.SpannedWidth(i, count, ds); width >= 0 {
+			fields = append(fields, cellStrs[i].WithinWidthAligned(width, colAligns[i]))
 			if ds.Inner != "" {
 				fields = append(fields, e.escStart+ds.Inner+e.escCellStart)
 			}
 		}
+		i += count
 	}
 	if ds.Right != "" && ds.Inner != "" {
 		fields[len(fields)-1] = e.escStart + ds.Right + eolReset
@@ -179,11 +377,26 @@ func (e emitter) commonRenderedLine(ds DividerSet, cellStrs []WidthString, colAl
 // this would be hidden.  It's used to render a single header line of a
 // texttable.
 func (e emitter) HeaderLineRendered(cellStrs []WidthString, colAligns []align.Alignment) string {
-	return e.commonRenderedLine(e.HeaderDividers(), cellStrs, colAligns)
+	return e.commonRenderedLine(e.HeaderDividers(), cellStrs, colAligns, nil)
 }
 
 // BodyLineRendered is internal to tabular, package predates 'internal' else
 // this would be hidden.  It's used to render a single line of a texttable.
 func (e emitter) BodyLineRendered(cellStrs []WidthString, colAligns []align.Alignment) string {
-	return e.commonRenderedLine(e.BodyDividers(), cellStrs, colAligns)
+	return e.commonRenderedLine(e.BodyDividers(), cellStrs, colAligns, nil)
+}
+
+// HeaderLineRenderedSpanned is HeaderLineRendered for a line with cells
+// spanning columns; see BodyLineRenderedSpanned.
+func (e emitter) HeaderLineRenderedSpanned(cellStrs []WidthString, colAligns []align.Alignment, spans []int) string {
+	return e.commonRenderedLine(e.HeaderDividers(), cellStrs, colAligns, spans)
+}
+
+// BodyLineRenderedSpanned is BodyLineRendered for a line with cells spanning
+// columns.  For each column, spans holds how many columns the field starting
+// there covers: 1 for an ordinary cell, more for a spanning cell, and 0 for a
+// column covered by a field to its left.  The content for a field is taken
+// from its first column; the alignment too.
+func (e emitter) BodyLineRenderedSpanned(cellStrs []WidthString, colAligns []align.Alignment, spans []int) string {
+	return e.commonRenderedLine(e.BodyDividers(), cellStrs, colAligns, spans)
 }
@@ -1,4 +1,4 @@
-// Copyright © 2016 Pennock Tech, LLC.
+// Copyright © 2016,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -104,6 +104,11 @@ func UTF8BoxHeavy() Decoration {
 		HBLeft:        "┣",
 		HBCross:       "╇",
 		HBRight:       "┫",
+		HBUp:          "┻",
+		RuleUp:        "┴",
+		RuleDown:      "┬",
+		HHCross:       "╋",
+		BFCross:       "┿",
 	}
 	d.Populate()
 	return d
@@ -136,6 +141,11 @@ func UTF8BoxDouble() Decoration {
 		HBLeft:        "╠",
 		HBCross:       "╪", // BROKEN: Unicode missing "BOX DRAWINGS DOWN SINGLE AND UP HORIZONTAL DOUBLE"?
 		HBRight:       "╣",
+		HBUp:          "╩",
+		RuleUp:        "┴",
+		RuleDown:      "┬",
+		HHCross:       "╬",
+		BFCross:       "╪",
 		// Do the doubling lines really not have an analogy to "BOX DRAWINGS DOWN LIGHT AND UP HORIZONTAL HEAVY"
 	}
 	d.Populate()
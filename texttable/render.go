@@ -1,4 +1,4 @@
-// Copyright © 2016,2018,2025 Pennock Tech, LLC.
+// Copyright © 2016,2018,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -8,9 +8,11 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 
 	"go.pennock.tech/tabular"
 	"go.pennock.tech/tabular/color"
+	"go.pennock.tech/tabular/length"
 	"go.pennock.tech/tabular/properties"
 	"go.pennock.tech/tabular/properties/align"
 	"go.pennock.tech/tabular/texttable/decoration"
@@ -57,31 +59,55 @@ func (t *TextTable) RenderTo(w io.Writer) error {
 	}
 
 	headers := t.Headers() // may be nil
+	var groupRows [][]tabular.Cell
+	if headers != nil {
+		for _, gr := range t.HeaderGroupRows() {
+			groupRows = append(groupRows, gr.Cells())
+		}
+	}
 
 	columnWidths := make([]int, columnCount)
 	columnAligns := make([]align.Alignment, columnCount)
 
-	if headers != nil {
-		for i := range columnWidths {
-			if i >= len(headers) {
+	// Cells spanning several columns don't contribute to the width of any one
+	// column; once the column widths are otherwise known, the last column
+	// covered is widened if need be.
+	var spanners []spanner
+	measure := func(cells []tabular.Cell, isHeader bool) {
+		for i := range cells {
+			if i >= columnCount {
 				break
 			}
-			columnWidths[i] = CellPropertyExtractDimensions(&headers[i]).cellWidth
+			if cells[i].SpanCovered() {
+				continue
+			}
+			d := CellPropertyExtractDimensions(&cells[i])
+			if columns, _ := cells[i].Span(); columns > 1 {
+				spanners = append(spanners, spanner{i, columns, d.cellWidth, isHeader})
+				continue
+			}
+			if d.cellWidth > columnWidths[i] {
+				columnWidths[i] = d.cellWidth
+			}
 		}
 	}
+
+	for _, cells := range groupRows {
+		measure(cells, true)
+	}
+	if headers != nil {
+		measure(headers, true)
+	}
 	for _, row := range t.AllRows() {
 		if row.IsSeparator() {
 			continue
 		}
-		for i, cell := range row.Cells() {
-			if i > columnCount {
-				break
-			}
-			d := CellPropertyExtractDimensions(&cell)
-			if d.cellWidth > columnWidths[i] {
-				columnWidths[i] = d.cellWidth
-			}
-		}
+		measure(row.Cells(), false)
+	}
+	var footerRows [][]tabular.Cell
+	for _, fr := range t.FooterRows() {
+		footerRows = append(footerRows, fr.Cells())
+		measure(fr.Cells(), false)
 	}
 
 	defaultAlignRaw := t.Column(0).GetProperty(align.PropertyType)
@@ -126,6 +152,26 @@ func (t *TextTable) RenderTo(w io.Writer) error {
 	}
 
 	emitter := t.decor.ForColumnWidths(columnWidths)
+	if len(spanners) > 0 {
+		// the emitter holds columnWidths, so sees each widening as we go
+		for _, s := range spanners {
+			ds := emitter.BodyDividers()
+			if s.isHeader {
+				ds = emitter.HeaderDividers()
+			}
+			have := emitter.SpannedWidth(s.column, s.columns, ds)
+			if have < 0 || have >= s.width {
+				continue
+			}
+			for last := min(s.column+s.columns, columnCount) - 1; last >= s.column; last-- {
+				if columnWidths[last] >= 0 {
+					columnWidths[last] += s.width - have
+					break
+				}
+			}
+		}
+		emitter = t.decor.ForColumnWidths(columnWidths)
+	}
 	emitter.SetEOL("\n")
 
 	colorON := t.colorBegin()
@@ -138,51 +184,269 @@ func (t *TextTable) RenderTo(w io.Writer) error {
 		emitter.SetNoResetEOL(true)
 	}
 
+	body, err := t.layoutBody(columnCount)
+	if err != nil {
+		return err
+	}
+	// spansNear finds the spans of a displayed row adjacent to a line.
+	spansNear := func(index int) []int {
+		if index < 0 || index >= len(body) || body[index].row.IsSeparator() {
+			return nil
+		}
+		return body[index].spans
+	}
+
+	title := textWidthString(t.Title())
+	subtitle := textWidthString(t.Subtitle())
+	overlayTitle := t.titleOverlay && emitter.TextFitsInLine(title)
+	overlaySubtitle := t.titleOverlay && emitter.TextFitsInLine(subtitle)
+	if !overlayTitle {
+		if err := writeBanner(w, title.S, emitter.TableWidth()); err != nil {
+			return err
+		}
+	}
+	if !overlaySubtitle && !overlayTitle {
+		if err := writeBanner(w, subtitle.S, emitter.TableWidth()); err != nil {
+			return err
+		}
+	}
+
 	if headers != nil {
-		if _, err := io.WriteString(w, emitter.LineHeaderTop()); err != nil {
+		// header groups, top-most first, then the column headers
+		headerRows := append(groupRows, headers)
+		var aboveSpans []int
+		for i, cells := range headerRows {
+			spans := spansOfRow(cells, columnCount)
+			var line string
+			if i == 0 && overlayTitle {
+				line = emitter.LineHeaderTopTitled(spans, title)
+			} else if i == 0 {
+				line = emitter.LineHeaderTopSpanned(spans)
+			} else {
+				line = emitter.LineHeaderGroupSepSpanned(aboveSpans, spans)
+			}
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+			for _, lineParts := range t.RowToLinesOfWidthStrings(cells, columnCount) {
+				if _, err := io.WriteString(w, emitter.HeaderLineRenderedSpanned(lineParts, columnAligns, spans)); err != nil {
+					return err
+				}
+			}
+			aboveSpans = spans
+		}
+		if _, err := io.WriteString(w, emitter.LineHeaderBodySepSpanned(aboveSpans, spansNear(0))); err != nil {
+			return err
+		}
+	} else if overlayTitle {
+		if _, err := io.WriteString(w, emitter.LineBodyTopTitled(spansNear(0), title)); err != nil {
+			return err
+		}
+	} else {
+		if _, err := io.WriteString(w, emitter.LineBodyTopSpanned(spansNear(0))); err != nil {
 			return err
 		}
-		for _, lineParts := range t.RowToLinesOfWidthStrings(headers, columnCount) {
-			if _, err := io.WriteString(w, emitter.HeaderLineRendered(lineParts, columnAligns)); err != nil {
+	}
+
+	for i, layout := range body {
+		// do we want a channel returning rows instead?  I don't _think_ so
+		if layout.row.IsSeparator() {
+			if _, err := io.WriteString(w, emitter.LineSeparatorSpanned(spansNear(i-1), spansNear(i+1))); err != nil {
+				return err
+			}
+			continue
+		}
+		for l := range layout.height {
+			lineParts := make([]decoration.WidthString, columnCount)
+			for c := range layout.lines {
+				if l < len(layout.lines[c]) {
+					lineParts[c] = layout.lines[c][l]
+				}
+			}
+			if _, err := io.WriteString(w, emitter.BodyLineRenderedSpanned(lineParts, columnAligns, layout.spans)); err != nil {
+				return err
+			}
+		}
+	}
+	lastSpans := spansNear(len(body) - 1)
+	for i, cells := range footerRows {
+		spans := spansOfRow(cells, columnCount)
+		if i == 0 && len(body) > 0 {
+			if _, err := io.WriteString(w, emitter.LineBodyFooterSepSpanned(lastSpans, spans)); err != nil {
 				return err
 			}
 		}
-		if _, err := io.WriteString(w, emitter.LineHeaderBodySep()); err != nil {
+		for _, lineParts := range t.RowToLinesOfWidthStrings(cells, columnCount) {
+			if _, err := io.WriteString(w, emitter.BodyLineRenderedSpanned(lineParts, columnAligns, spans)); err != nil {
+				return err
+			}
+		}
+		lastSpans = spans
+	}
+	if overlaySubtitle {
+		if _, err := io.WriteString(w, emitter.LineBottomTitled(lastSpans, subtitle)); err != nil {
 			return err
 		}
 	} else {
-		if _, err := io.WriteString(w, emitter.LineBodyTop()); err != nil {
+		if _, err := io.WriteString(w, emitter.LineBottomSpanned(lastSpans)); err != nil {
 			return err
 		}
+		if overlayTitle {
+			// the subtitle didn't fit into the bottom line; we're not going
+			// to put it above the title, so it goes beneath the table
+			if err := writeBanner(w, subtitle.S, emitter.TableWidth()); err != nil {
+				return err
+			}
+		}
 	}
+	// do _not_ try to close the writer, that's not ours
+	return nil
+}
 
-	var skipRow bool
-	var err error
-	for rowNum, row := range t.AllRows() {
-		if skipRow, err = properties.ExpectBoolPropertyOrNil(properties.Omit, row.GetProperty(properties.Omit), "text:renderTo", "row", rowNum+1); err != nil {
+// textWidthString measures a title for overlaying onto a line; text with
+// more than one line is given a negative width, so that it is never
+// overlaid.
+func textWidthString(s string) decoration.WidthString {
+	if strings.Contains(s, "\n") {
+		return decoration.WidthString{S: s, W: -1}
+	}
+	return decoration.WidthString{S: s, W: length.StringCells(s)}
+}
+
+// writeBanner writes text centered over a table of the given width, for a
+// title or subtitle.
+func writeBanner(w io.Writer, text string, width int) error {
+	if text == "" {
+		return nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		pad := max(width-length.StringCells(line), 0) / 2
+		if _, err := io.WriteString(w, strings.Repeat(" ", pad)+line+"\n"); err != nil {
 			return err
 		}
+	}
+	return nil
+}
+
+// spanner records a cell spanning columns, for sizing the columns.
+type spanner struct {
+	column   int // 0-based
+	columns  int
+	width    int
+	isHeader bool
+}
+
+// rowLayout is a body row prepared for display, with the content of cells
+// spanning rows distributed across the rows which they cover.
+type rowLayout struct {
+	row    *tabular.Row
+	height int
+	lines  [][]decoration.WidthString // per column, the lines to show
+	spans  []int                      // as for decoration BodyLineRenderedSpanned
+}
+
+// layoutBody prepares the body rows for display, skipping omitted rows.
+func (t *TextTable) layoutBody(columnCount int) ([]*rowLayout, error) {
+	allRows := t.AllRows()
+	body := make([]*rowLayout, 0, len(allRows))
+	for rowNum, row := range allRows {
+		skipRow, err := properties.ExpectBoolPropertyOrNil(properties.Omit, row.GetProperty(properties.Omit), "text:renderTo", "row", rowNum+1)
+		if err != nil {
+			return nil, err
+		}
 		if skipRow {
 			continue
 		}
-		// do we want a channel returning rows instead?  I don't _think_ so
+		layout := &rowLayout{row: row, height: 1}
+		body = append(body, layout)
 		if row.IsSeparator() {
-			if _, err := io.WriteString(w, emitter.LineSeparator()); err != nil {
-				return err
+			continue
+		}
+		cells := row.Cells()
+		layout.lines = make([][]decoration.WidthString, min(columnCount, len(cells)))
+		for i := range layout.lines {
+			if cells[i].SpanCovered() {
+				continue
+			}
+			layout.lines[i] = CellPropertyExtractLinesWidths(&cells[i])
+			if _, rows := cells[i].Span(); rows == 1 {
+				layout.height = max(layout.height, len(layout.lines[i]))
 			}
+		}
+		layout.spans = spansOfRow(cells, columnCount)
+	}
+
+	// A cell spanning rows has its lines shared across the displayed rows
+	// which it covers, with the last of those made taller if need be.  We
+	// need all the heights settled before we can share out the lines.
+	type region struct {
+		column  int
+		lines   []decoration.WidthString
+		members []*rowLayout
+	}
+	var regions []region
+	for k, layout := range body {
+		if layout.row.IsSeparator() {
 			continue
 		}
-		for _, lineParts := range t.RowToLinesOfWidthStrings(row.Cells(), columnCount) {
-			if _, err := io.WriteString(w, emitter.BodyLineRendered(lineParts, columnAligns)); err != nil {
-				return err
+		cells := layout.row.Cells()
+		for i := range layout.lines {
+			if _, rows := cells[i].Span(); rows < 2 {
+				continue
+			}
+			anchor := &cells[i]
+			r := region{column: i, lines: layout.lines[i], members: []*rowLayout{layout}}
+			total := layout.height
+			for _, next := range body[k+1:] {
+				nextCells := next.row.Cells()
+				if next.row.IsSeparator() || i >= len(nextCells) || nextCells[i].SpanAnchor() != anchor {
+					break
+				}
+				r.members = append(r.members, next)
+				total += next.height
+			}
+			if need := len(r.lines); need > total {
+				r.members[len(r.members)-1].height += need - total
 			}
+			regions = append(regions, r)
 		}
 	}
-	if _, err := io.WriteString(w, emitter.LineBottom()); err != nil {
-		return err
+	for _, r := range regions {
+		offset := 0
+		for _, member := range r.members {
+			if r.column < len(member.lines) {
+				member.lines[r.column] = r.lines[min(offset, len(r.lines)):]
+			}
+			offset += member.height
+		}
 	}
-	// do _not_ try to close the writer, that's not ours
-	return nil
+
+	return body, nil
+}
+
+// spansOfRow returns the spans of the cells of a row, in the form used by
+// the decoration emitter, or nil if nothing in the row spans columns.
+func spansOfRow(cells []tabular.Cell, columnCount int) []int {
+	var spans []int
+	for i := range min(columnCount, len(cells)) {
+		anchor := cells[i].SpanAnchor()
+		columns, _ := anchor.Span()
+		if columns == 1 && anchor == &cells[i] {
+			continue
+		}
+		if spans == nil {
+			spans = make([]int, columnCount)
+			for j := range spans {
+				spans[j] = 1
+			}
+		}
+		if anchor.Location().Column == i+1 {
+			spans[i] = columns
+		} else {
+			spans[i] = 0
+		}
+	}
+	return spans
 }
 
 func (t *TextTable) RowToLinesOfWidthStrings(
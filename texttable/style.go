@@ -1,4 +1,4 @@
-// Copyright © 2016,2025 Pennock Tech, LLC.
+// Copyright © 2016,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -119,3 +119,13 @@ func (t *TextTable) RemoveCellBGColor(c color.Color) *TextTable {
 	t.cellbgcolor = nil
 	return t
 }
+
+// SetTitleOverlay controls how the table's title and subtitle are shown: by
+// default they are a centered banner above the table, but with overlay on,
+// the title is drawn into the top border line and the subtitle into the
+// bottom border line.  Anything which doesn't fit within the border, or a
+// table without borders, falls back to a banner.
+func (t *TextTable) SetTitleOverlay(onoff bool) *TextTable {
+	t.titleOverlay = onoff
+	return t
+}
@@ -268,3 +268,199 @@ func TestTableBoxless(t *testing.T) {
 	T.Equal(tb.Errors(), nil, "no errors rendering table (boxless)")
 	T.Equal(rendered, should, "simple table rendered correctly (boxless)")
 }
+
+func createSpanningTableContents(T *testlib.T) *texttable.TextTable {
+	tb := texttable.New()
+	T.NotEqual(tb, nil, "have a table")
+
+	tb.AddHeaders(tabular.NewSpanningCell("latency", 2, 1), "unit")
+	tb.AddRowItems(tabular.NewSpanningCell("p50\nweekday\nonly", 1, 2), 3, "ms")
+	tb.AddRowItems(4, "ms")
+	tb.AddSeparator()
+	tb.AddRowItems(tabular.NewSpanningCell("not measured this week", 2, 1), "-")
+	tb.AddSeparator()
+	tb.AddRowItems("p99", 40, "ms")
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	return tb
+}
+
+func TestTableRenderingSpans(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+	tb := createSpanningTableContents(T)
+
+	should := "" +
+		"┏━━━━━━━━━━━━━━━━━━━━━━━━┳━━━━━━┓\n" +
+		"┃ latency                ┃ unit ┃\n" +
+		"┣━━━━━━━━━┯━━━━━━━━━━━━━━╇━━━━━━┫\n" +
+		"┃ p50     │ 3            │ ms   ┃\n" +
+		"┃ weekday │ 4            │ ms   ┃\n" +
+		"┃ only    │              │      ┃\n" +
+		"┠─────────┴──────────────┼──────┨\n" +
+		"┃ not measured this week │ -    ┃\n" +
+		"┠─────────┬──────────────┼──────┨\n" +
+		"┃ p99     │ 40           │ ms   ┃\n" +
+		"┗━━━━━━━━━┷━━━━━━━━━━━━━━┷━━━━━━┛\n" +
+		""
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "spanning table rendered (default style)")
+	T.Equal(tb.Errors(), nil, "no errors rendering spanning table (default style)")
+	T.Equal(rendered, should, "spanning table rendered correctly (default style)")
+
+	should = "" +
+		"latency                unit\n" +
+		"p50     3              ms  \n" +
+		"weekday 4              ms  \n" +
+		"only                       \n" +
+		"not measured this week -   \n" +
+		"p99     40             ms  \n" +
+		""
+	_, err = tb.SetDecorationNamed(decoration.D_NONE)
+	T.ExpectSuccess(err, "set a const decoration")
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "spanning table rendered (boxless)")
+	T.Equal(rendered, should, "spanning table rendered correctly (boxless)")
+}
+
+func TestTableRenderingHeaderGroups(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := texttable.New()
+	tb.AddHeaderGroups(tabular.HeaderGroup{Columns: 1}, tabular.HeaderGroup{Name: "Latency", Columns: 3})
+	tb.AddHeaders("host", "p50", "p95", "p99")
+	tb.AddRowItems("alpha", 3, 9, 40)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := "" +
+		"┏━━━━━━━┳━━━━━━━━━━━━━━━━━┓\n" +
+		"┃       ┃ Latency         ┃\n" +
+		"┣━━━━━━━╋━━━━━┳━━━━━┳━━━━━┫\n" +
+		"┃ host  ┃ p50 ┃ p95 ┃ p99 ┃\n" +
+		"┣━━━━━━━╇━━━━━╇━━━━━╇━━━━━┫\n" +
+		"┃ alpha │ 3   │ 9   │ 40  ┃\n" +
+		"┗━━━━━━━┷━━━━━┷━━━━━┷━━━━━┛\n" +
+		""
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "grouped table rendered (default style)")
+	T.Equal(tb.Errors(), nil, "no errors rendering grouped table")
+	T.Equal(rendered, should, "grouped table rendered correctly (default style)")
+
+	should = "" +
+		"┌───────┬─────────────────┐\n" +
+		"│       │ Latency         │\n" +
+		"├───────┼─────┬─────┬─────┤\n" +
+		"│ host  │ p50 │ p95 │ p99 │\n" +
+		"├───────┼─────┼─────┼─────┤\n" +
+		"│ alpha │ 3   │ 9   │ 40  │\n" +
+		"└───────┴─────┴─────┴─────┘\n" +
+		""
+	_, err = tb.SetDecorationNamed(decoration.D_UTF8_LIGHT)
+	T.ExpectSuccess(err, "set a const decoration")
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "grouped table rendered (light style)")
+	T.Equal(rendered, should, "grouped table rendered correctly (light style)")
+}
+
+func TestTableRenderingTitles(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+	tb := createStdTableContents(T)
+	tb.SetTitle("Words")
+	tb.SetSubtitle("as seen")
+
+	should := "" +
+		"            Words\n" +
+		"           as seen\n" +
+		"┏━━━━━━━━┳━━━━━━━━━━━━┳━━━━━━┓\n" +
+		"┃ foo    ┃ loquacious ┃ x    ┃\n" +
+		"┣━━━━━━━━╇━━━━━━━━━━━━╇━━━━━━┫\n" +
+		"┃ 42     │ .          │ fred ┃\n" +
+		"┃ snerty │ word       │ r    ┃\n" +
+		"┠────────┼────────────┼──────┨\n" +
+		"┃        │ true       │      ┃\n" +
+		"┗━━━━━━━━┷━━━━━━━━━━━━┷━━━━━━┛\n" +
+		""
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "titled table rendered (banner)")
+	T.Equal(rendered, should, "titled table rendered correctly (banner)")
+
+	should = "" +
+		"┏━ Words ┳━━━━━━━━━━━━┳━━━━━━┓\n" +
+		"┃ foo    ┃ loquacious ┃ x    ┃\n" +
+		"┣━━━━━━━━╇━━━━━━━━━━━━╇━━━━━━┫\n" +
+		"┃ 42     │ .          │ fred ┃\n" +
+		"┃ snerty │ word       │ r    ┃\n" +
+		"┠────────┼────────────┼──────┨\n" +
+		"┃        │ true       │      ┃\n" +
+		"┗━ as seen ━━━━━━━━━━━┷━━━━━━┛\n" +
+		""
+	rendered, err = tb.SetTitleOverlay(true).Render()
+	T.ExpectSuccess(err, "titled table rendered (overlay)")
+	T.Equal(rendered, should, "titled table rendered correctly (overlay)")
+
+	tb.SetSubtitle("far too long to fit in the bottom line")
+	should = "" +
+		"┏━ Words ┳━━━━━━━━━━━━┳━━━━━━┓\n" +
+		"┃ foo    ┃ loquacious ┃ x    ┃\n" +
+		"┣━━━━━━━━╇━━━━━━━━━━━━╇━━━━━━┫\n" +
+		"┃ 42     │ .          │ fred ┃\n" +
+		"┃ snerty │ word       │ r    ┃\n" +
+		"┠────────┼────────────┼──────┨\n" +
+		"┃        │ true       │      ┃\n" +
+		"┗━━━━━━━━┷━━━━━━━━━━━━┷━━━━━━┛\n" +
+		"far too long to fit in the bottom line\n" +
+		""
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "titled table rendered (overlay, long subtitle)")
+	T.Equal(rendered, should, "long subtitle falls back to beneath the table")
+}
+
+func TestTableRenderingFooter(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := texttable.New()
+	tb.AddHeaders("Item", "Qty", "Price")
+	tb.AddRowItems("apple", 3, 1.5)
+	tb.AddRowItems("pear", 2, 2.25)
+	tb.AddFooterItems(tabular.NewSpanningCell("before tax", 2, 1), 0.5)
+	tb.SetTotalsLabel("Total")
+	tb.Column(2).SetAggregator(tabular.AGG_SUM)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	T.Equal(tb.Errors(), nil, "no errors just adding items")
+
+	should := "" +
+		"┏━━━━━━━┳━━━━━┳━━━━━━━┓\n" +
+		"┃ Item  ┃ Qty ┃ Price ┃\n" +
+		"┣━━━━━━━╇━━━━━╇━━━━━━━┫\n" +
+		"┃ apple │ 3   │ 1.5   ┃\n" +
+		"┃ pear  │ 2   │ 2.25  ┃\n" +
+		"┣━━━━━━━┷━━━━━┿━━━━━━━┫\n" +
+		"┃ before tax  │ 0.5   ┃\n" +
+		"┃ Total │ 5   │ 3.75  ┃\n" +
+		"┗━━━━━━━┷━━━━━┷━━━━━━━┛\n" +
+		""
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "table with footer rendered (default style)")
+	T.Equal(tb.Errors(), nil, "no errors rendering table with footer")
+	T.Equal(rendered, should, "table with footer rendered correctly (default style)")
+
+	should = "" +
+		"+-------+-----+-------+\n" +
+		"| Item  | Qty | Price |\n" +
+		"+-------+-----+-------+\n" +
+		"| apple | 3   | 1.5   |\n" +
+		"| pear  | 2   | 2.25  |\n" +
+		"+-------+-----+-------+\n" +
+		"| before tax  | 0.5   |\n" +
+		"| Total | 5   | 3.75  |\n" +
+		"+-------+-----+-------+\n" +
+		""
+	_, err = tb.SetDecorationNamed(decoration.D_ASCII_SIMPLE)
+	T.ExpectSuccess(err, "set a const decoration")
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "table with footer rendered (ascii style)")
+	T.Equal(rendered, should, "table with footer rendered correctly (ascii style)")
+}
@@ -1,4 +1,4 @@
-// Copyright © 2016,2025 Pennock Tech, LLC.
+// Copyright © 2016,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -29,6 +29,8 @@ type TextTable struct {
 	cellfgcolor *color.Color
 	cellbgcolor *color.Color
 	bgflags     colorFlags
+
+	titleOverlay bool
 }
 
 // Wrap returns a TextTable rendering object for the given tabular.Table
@@ -0,0 +1,30 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+// SetTitle sets a title for the table, for renderers to show with it, so
+// that readers can tell apart several tables shown together.  An empty
+// title means none.  The table is returned.
+func (t *ATable) SetTitle(title string) Table {
+	t.title = title
+	return t
+}
+
+// Title returns the table's title, which is empty if none has been set.
+func (t *ATable) Title() string {
+	return t.title
+}
+
+// SetSubtitle sets a subtitle, or caption, for the table; renderers show
+// this less prominently than the title.  The table is returned.
+func (t *ATable) SetSubtitle(subtitle string) Table {
+	t.subtitle = subtitle
+	return t
+}
+
+// Subtitle returns the table's subtitle, which is empty if none has been set.
+func (t *ATable) Subtitle() string {
+	return t.subtitle
+}
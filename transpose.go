@@ -0,0 +1,91 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+// Transpose returns a new table in which each column of this table is a row
+// and each row a column: the headers, if any, become the first column, and
+// the rows of the body, skipping separators, become the columns after that.
+// This suits showing one record with many fields, as a column of field
+// names beside a column of values.
+//
+// The cells are copies, carrying copies of their properties and callbacks
+// across, and a cell spanning columns spans rows instead, and vice versa.
+// The properties of each column are copied to its row, and the properties of
+// each row to its column, so that properties.Omit still hides the same cells;
+// changing properties of the new table leaves this one alone.  The title and
+// subtitle are carried across; header groups and footer rows, including any
+// totals row, are not.
+func (t *ATable) Transpose() Table {
+	return transpose(t)
+}
+
+// Transpose returns a new table holding the view, transposed.
+func (fv *FilterView) Transpose() Table {
+	return transpose(fv)
+}
+
+// Transpose returns a new table holding the projection, transposed.
+func (pv *ProjectionView) Transpose() Table {
+	return transpose(pv)
+}
+
+func transpose(t Table) *ATable {
+	nt := New()
+	nt.SetTitle(t.Title())
+	nt.SetSubtitle(t.Subtitle())
+
+	var body []*Row
+	for _, row := range t.AllRows() {
+		if !row.IsSeparator() {
+			body = append(body, row)
+		}
+	}
+	headers := t.Headers()
+	for k := 0; k < t.NColumns(); k++ {
+		row := NewRowWithCapacity(len(body) + 1)
+		if column := t.Column(k + 1); column != nil {
+			row.propertyImpl = column.propertyImpl.clone()
+		}
+		if headers != nil {
+			row.addTransposed(headers, k)
+		}
+		for _, r := range body {
+			row.addTransposed(r.Cells(), k)
+		}
+		nt.AddRow(row)
+	}
+
+	offset := 1
+	if headers != nil {
+		offset = 2
+	}
+	for j, r := range body {
+		if column := nt.Column(offset + j); column != nil {
+			column.propertyImpl = r.propertyImpl.clone()
+		}
+	}
+	return nt
+}
+
+// addTransposed adds to the row a copy of the cell at 0-based index k of a
+// row being transposed, with its span turned about; placeholders are skipped,
+// since Add and AddRow put them back in their transposed positions.
+func (r *Row) addTransposed(cells []Cell, k int) {
+	if k >= len(cells) {
+		r.Add(NewCell(nil))
+		return
+	}
+	if cells[k].SpanCovered() {
+		return
+	}
+	c := cells[k]
+	c.propertyImpl = c.propertyImpl.clone()
+	c.callbacks = c.callbacks.clone()
+	columns, rows := c.Span()
+	c.inRow = nil
+	c.columnNum = 0
+	c.span = cellSpan{columns: rows, rows: columns}
+	r.Add(c)
+}
@@ -0,0 +1,73 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/properties"
+	"go.pennock.tech/tabular/properties/align"
+	"go.pennock.tech/tabular/texttable"
+)
+
+func TestTranspose(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.SetTitle("Hosts")
+	tb.AddHeaders("Name", "Address", "Port")
+	tb.AddRowItems("alpha", "192.0.2.1", 22)
+	tb.AddSeparator()
+	tb.AddRowItems("beta", tabular.NewSpanningCell("unreachable", 2, 1))
+	tb.AllRows()[0].Cells()[2].SetProperty(align.PropertyType, align.Right)
+	tb.AllRows()[2].SetProperty("marker", "beta row")
+	tb.Column(2).SetProperty(properties.Omit, true)
+	// Properties set later sit above those being changed in the copies.
+	tb.AllRows()[0].Cells()[2].SetProperty("marker", "port cell")
+	tb.AllRows()[2].SetProperty("note", "beta note")
+	tb.Column(2).SetProperty("marker", "address column")
+
+	tr := tb.Transpose()
+	T.Equal(tr.NRows(), 3, "one row per column")
+	T.Equal(tr.NColumns(), 3, "headers, then one column per row")
+	T.Equal(tr.Headers(), []tabular.Cell(nil), "no headers")
+	T.Equal(firstColumn(tr), []string{"Name", "Address", "Port"}, "headers down the first column")
+	T.Equal(columnStrings(tr, 2), []string{"alpha", "192.0.2.1", "22"}, "row became a column")
+	T.Equal(tr.AllRows()[2].Cells()[1].GetProperty(align.PropertyType), align.Right, "cell properties carried")
+	T.Equal(tr.Column(3).GetProperty("marker"), "beta row", "row properties became column properties")
+	T.Equal(tr.AllRows()[1].GetProperty(properties.Omit), true, "column properties became row properties")
+	columns, rows := tr.AllRows()[1].Cells()[2].Span()
+	T.Equal([]int{columns, rows}, []int{1, 2}, "span turned about")
+	T.Equal(tr.AllRows()[2].Cells()[2].SpanCovered(), true, "placeholder flowed into place")
+	checkLocations(T, tr)
+
+	tr.AllRows()[1].SetProperty(properties.Omit, false)
+	tr.AllRows()[2].Cells()[1].SetProperty(align.PropertyType, align.Left)
+	tr.Column(3).SetProperty("marker", "changed")
+	T.Equal(tb.Column(2).GetProperty(properties.Omit), true, "original column properties unchanged")
+	T.Equal(tb.AllRows()[0].Cells()[2].GetProperty(align.PropertyType), align.Right, "original cell properties unchanged")
+	T.Equal(tb.AllRows()[2].GetProperty("marker"), "beta row", "original row properties unchanged")
+	tr.AllRows()[2].Cells()[1].SetProperty(align.PropertyType, align.Right)
+	text := texttable.Wrap(tr)
+	_, err := text.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	rendered, err := text.Render()
+	T.ExpectSuccess(err, "transposed table rendered")
+	T.Equal(rendered, strings.TrimLeft(`
+                Hosts
++---------+-----------+-------------+
+| Name    | alpha     | beta        |
+| Address | 192.0.2.1 | unreachable |
+| Port    | 22        |             |
++---------+-----------+-------------+
+`, "\n"), "transposed table rendered")
+	T.Equal(tb.Errors(), nil, "no errors transposing")
+	T.Equal(tr.Errors(), nil, "no errors in transposed table")
+}
@@ -1,4 +1,4 @@
-// Copyright © 2016,2025 Pennock Tech, LLC.
+// Copyright © 2016,2025,2026 Pennock Tech, LLC.
 // All rights reserved, except as granted under license.
 // Licensed per file LICENSE.txt
 
@@ -24,12 +24,15 @@ type SortInter interface {
 }
 
 // Fielder for ability to grab "a row" from a type.
+// An item which is a Fielder becomes a cell for each field when added to a
+// row of a table, whether by AddRowItems, AddRow or AddHeaders.
 type Fielder interface {
 	Fields() []string
 }
 
 // AnonFielder to avoid caller having to iterate an []interface{}
 // to construct strings; we then just do cell breakdown on each.
+// It is preferred to Fielder, for an item satisfying both.
 type AnonFielder interface {
 	AnonFields() []any
 }
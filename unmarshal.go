@@ -0,0 +1,188 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ErrNotStructSlicePointer is returned by UnmarshalRows when not given a
+// pointer to a slice of structs, or of pointers to structs.
+var ErrNotStructSlicePointer = errors.New("not a pointer to a slice of structs")
+
+// ErrorUnmarshalCell is returned by UnmarshalRows for a cell which could not
+// be stored in its field.
+type ErrorUnmarshalCell struct {
+	Location CellLocation
+	Field    string
+	Err      error
+}
+
+func (e ErrorUnmarshalCell) Error() string {
+	return fmt.Sprintf("tabular: cell at [row %d, col %d] for field %s: %v", e.Location.Row, e.Location.Column, e.Field, e.Err)
+}
+
+func (e ErrorUnmarshalCell) Unwrap() error { return e.Err }
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// UnmarshalRows fills a slice of structs, or of pointers to structs, from the
+// rows of the body of a table, one struct per row, appending to the slice
+// pointed to by dest.  Fields are matched to columns by name, as AddStructs
+// names the columns of fields, including by struct tag; fields and columns
+// without a match are left alone.  Separators, subtotal rows and rows marked
+// with properties.Omit are skipped.
+//
+// Where the item held in a cell can be assigned to its field, it is.
+// Otherwise the String form of the cell is parsed for the field, using
+// encoding.TextUnmarshaler if the field's type supports it, or else per the
+// kind of the field, with time.Duration parsed as a duration.  Empty cells
+// leave their fields at the zero value.  Each cell which could not be stored
+// is reported as an ErrorUnmarshalCell, all joined into the error returned,
+// with the rest of the fields filled regardless.
+func UnmarshalRows(t Table, dest any) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Slice {
+		return ErrNotStructSlicePointer
+	}
+	sv := dv.Elem()
+	et := sv.Type().Elem()
+	st := et
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	if st.Kind() != reflect.Struct {
+		return ErrNotStructSlicePointer
+	}
+	fields, err := structFields(st)
+	if err != nil {
+		return err
+	}
+	columns := make([]int, len(fields))
+	for i := range fields {
+		if n, err := columnNumberNamed(t, fields[i].name); err == nil {
+			columns[i] = n
+		} else if err == ErrNoColumnHeaders {
+			return err
+		}
+	}
+
+	var errs []error
+	for _, row := range dataRows(t) {
+		item := reflect.New(st).Elem()
+		for i := range fields {
+			n := columns[i]
+			if n == 0 || n > len(row.cells) {
+				continue
+			}
+			c := row.cells[n-1].SpanAnchor()
+			if c.Empty() {
+				continue
+			}
+			fv, err := fieldAllocating(item, fields[i].index)
+			if err == nil {
+				err = setFromCell(fv, c)
+			}
+			if err != nil {
+				errs = append(errs, ErrorUnmarshalCell{
+					Location: CellLocation{Row: row.rowNum, Column: n},
+					Field:    fields[i].name,
+					Err:      err,
+				})
+			}
+		}
+		if et.Kind() == reflect.Pointer {
+			item = item.Addr()
+		}
+		sv.Set(reflect.Append(sv, item))
+	}
+	return errors.Join(errs...)
+}
+
+// fieldAllocating returns the field at the index path within v, allocating
+// any nil embedded struct pointers on the way.
+func fieldAllocating(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot allocate embedded %s", v.Type())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// setFromCell stores the content of a cell into a field.
+func setFromCell(fv reflect.Value, c *Cell) error {
+	raw := c.innermost().raw
+	if rv := reflect.ValueOf(raw); rv.IsValid() && rv.Type().AssignableTo(fv.Type()) {
+		fv.Set(rv)
+		return nil
+	}
+	if fv.Kind() == reflect.Pointer {
+		p := reflect.New(fv.Type().Elem())
+		if err := setFromCell(p.Elem(), c); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+	s := c.String()
+	if reflect.PointerTo(fv.Type()).Implements(textUnmarshalerType) {
+		return fv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot store %T in %s", raw, fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+)
+
+func TestRowCellNamed(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	populateJobs(tb)
+	tb.AddRowItems(tabular.NewSpanningCell("cleanup", 2, 1))
+	row := tb.AllRows()[1]
+
+	c, err := row.CellNamed("Minutes")
+	T.ExpectSuccess(err, "cell found by name")
+	T.Equal(c.Item(), 12, "cell named")
+	_, err = row.CellNamed("Owner")
+	T.Equal(err, tabular.ErrorNoSuchColumn("Owner"), "unknown column")
+	_, err = tabular.NewRow().CellNamed("Job")
+	T.Equal(err, tabular.ErrRowNotInTable, "row not in a table")
+
+	short := tb.AllRows()[7]
+	c, err = short.CellNamed("State")
+	T.ExpectSuccess(err, "covered cell found by name")
+	T.Equal(c.String(), "cleanup", "spanning cell for covered column")
+	_, err = short.CellNamed("Minutes")
+	T.Equal(err, tabular.NoSuchCellError{Location: tabular.CellLocation{Row: 8, Column: 3}}, "beyond a short row")
+
+	T.Equal(row.AsMap(), map[string]any{"Job": "test", "State": "running", "Minutes": 12}, "row as a map")
+	T.Equal(short.AsMap(), map[string]any{"Job": "cleanup", "State": "cleanup"}, "short row as a map")
+	T.Equal(tb.AllRows()[2].AsMap(), map[string]any(nil), "separator has no map")
+}
+
+type jobRecord struct {
+	Name    string `tabular:"Job"`
+	State   *string
+	Minutes time.Duration
+	Retries uint8
+	Host    netip.Addr
+	Ignored string `tabular:"-"`
+}
+
+func TestUnmarshalRows(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Job", "State", "Minutes", "Retries", "Host", "Ignored")
+	tb.AddRowItems("build", "done", 4*time.Minute, "2", "192.0.2.1", "x")
+	tb.AddSeparator()
+	tb.AddRowItems("test", nil, "90s", uint8(0), netip.MustParseAddr("2001:db8::1"))
+
+	var jobs []jobRecord
+	T.ExpectSuccess(tabular.UnmarshalRows(tb, &jobs), "unmarshal rows")
+	done := "done"
+	T.Equal(jobs, []jobRecord{
+		{Name: "build", State: &done, Minutes: 4 * time.Minute, Retries: 2, Host: netip.MustParseAddr("192.0.2.1")},
+		{Name: "test", Minutes: 90 * time.Second, Host: netip.MustParseAddr("2001:db8::1")},
+	}, "rows unmarshalled by name and tag, assigned or parsed")
+
+	tb.AddRowItems("lint", "done", "soon", 300, "nowhere")
+	var ptrs []*jobRecord
+	err := tabular.UnmarshalRows(tb, &ptrs)
+	T.Equal(len(ptrs), 3, "every row unmarshalled despite errors")
+	T.Equal(ptrs[2].Name, "lint", "good fields filled")
+	var cellErr tabular.ErrorUnmarshalCell
+	T.Equal(errors.As(err, &cellErr), true, "cell errors reported")
+	T.Equal(cellErr.Location, tabular.CellLocation{Row: 4, Column: 3}, "first bad cell located")
+	var numErr *strconv.NumError
+	T.Equal(errors.As(err, &numErr), true, "parse errors wrapped")
+	T.Equal(len(err.(interface{ Unwrap() []error }).Unwrap()), 3, "each bad cell reported")
+
+	T.Equal(tabular.UnmarshalRows(tb, jobs), tabular.ErrNotStructSlicePointer, "pointer needed")
+	T.Equal(tabular.UnmarshalRows(tabular.New(), &jobs), tabular.ErrNoColumnHeaders, "headers needed")
+}
@@ -0,0 +1,96 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+)
+
+type recordingCallback struct {
+	label string
+	log   *[]string
+}
+
+func (rc recordingCallback) UpdateProperties(owner tabular.PropertyOwner) error {
+	switch o := owner.(type) {
+	case *tabular.Cell:
+		*rc.log = append(*rc.log, rc.label+":"+o.String())
+	default:
+		*rc.log = append(*rc.log, fmt.Sprintf("%s:%T", rc.label, owner))
+	}
+	return nil
+}
+
+func TestSetItem(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	populateJobs(tb)
+	tb.AddRowItems(tabular.NewSpanningCell("cleanup", 2, 1), 5)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+
+	var log []string
+	row := tb.AllRows()[0]
+	for i, err := range []error{
+		tb.RegisterPropertyCallback(tb.Table, tabular.CB_AT_UPDATE, tabular.CB_ON_CELL, recordingCallback{"table-cell", &log}),
+		tb.RegisterPropertyCallback(tb.Table, tabular.CB_AT_UPDATE, tabular.CB_ON_ROW, recordingCallback{"table-row", &log}),
+		tb.RegisterPropertyCallback(tb.Table, tabular.CB_AT_UPDATE, tabular.CB_ON_ITSELF, recordingCallback{"table", &log}),
+		tb.RegisterPropertyCallback(tb.Column(3), tabular.CB_AT_UPDATE, tabular.CB_ON_CELL, recordingCallback{"column-cell", &log}),
+		tb.RegisterPropertyCallback(tb.Column(3), tabular.CB_AT_UPDATE, tabular.CB_ON_ITSELF, recordingCallback{"column", &log}),
+		tb.RegisterPropertyCallback(row, tabular.CB_AT_UPDATE, tabular.CB_ON_CELL, recordingCallback{"row-cell", &log}),
+		tb.RegisterPropertyCallback(row, tabular.CB_AT_UPDATE, tabular.CB_ON_ITSELF, recordingCallback{"row", &log}),
+		tb.RegisterPropertyCallback(&row.Cells()[2], tabular.CB_AT_UPDATE, tabular.CB_ON_ITSELF, recordingCallback{"cell", &log}),
+	} {
+		T.ExpectSuccess(err, fmt.Sprintf("register update callback %d", i))
+	}
+
+	row.Cells()[2].SetItem(40)
+	T.Equal(log, []string{
+		"cell:40", "row-cell:40", "column-cell:40", "table-cell:40",
+		"row:*tabular.Row", "table-row:*tabular.Row", "column:*tabular.Column", "table:*tabular.ATable",
+	}, "update callbacks invoked, cell outwards")
+
+	log = nil
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 2, Column: 1}, "retest"), "set cell by location")
+	T.Equal(log, []string{"table-cell:retest", "table-row:*tabular.Row", "table:*tabular.ATable"}, "callbacks of the cell's own row and column")
+	T.ExpectSuccess(tb.SetCellAt(tabular.CellLocation{Row: 8, Column: 2}, "purge"), "set covered cell")
+	T.Equal(tb.AllRows()[7].Cells()[0].String(), "purge", "spanning cell changed")
+	T.Equal(tb.SetCellAt(tabular.CellLocation{Row: 9, Column: 1}, "x"), tabular.NoSuchCellError{Location: tabular.CellLocation{Row: 9, Column: 1}}, "no such cell")
+
+	running := tb.Filter(stateIs("running"))
+	T.ExpectSuccess(running.SetCellAt(tabular.CellLocation{Row: 3, Column: 3}, 20), "set cell through a view")
+
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "changed table rendered")
+	T.Equal(rendered, `"Job","State","Minutes"
+"build","done","40"
+"retest","running","12"
+"lint","done","1"
+"deploy","queued","0"
+"notify","running","20"
+"purge","purge","5"
+"Total","","78"
+`, "changes rendered, with totals recomputed")
+	T.Equal(tb.Errors(), nil, "no errors changing cells")
+
+	schema := tabular.New()
+	schema.SetSchema(tabular.Schema{{Name: "Load", Type: reflect.TypeOf(0.0), Format: "%.1f"}})
+	schema.AddRowItems(1.0)
+	T.ExpectSuccess(schema.SetCellAt(tabular.CellLocation{Row: 1, Column: 1}, 2.25), "change cell with schema")
+	T.Equal(columnStrings(schema, 1), []string{"2.2"}, "format reapplied on update")
+	T.ExpectSuccess(schema.SetCellAt(tabular.CellLocation{Row: 1, Column: 1}, "high"), "change cell against schema")
+	T.Equal(schema.Errors(), []error{
+		tabular.ErrorSchemaViolation{Location: tabular.CellLocation{Row: 1, Column: 1}, Column: "Load", Problem: "holds string, not float64"},
+	}, "schema checked on update")
+}
@@ -0,0 +1,576 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular // import "go.pennock.tech/tabular"
+
+import (
+	"errors"
+
+	"go.pennock.tech/tabular/properties"
+)
+
+// Views
+//
+// A view is a Table which shows another table differently, without copying
+// its cells: the rows and cells seen through a view are those of the table
+// beneath, so changes to either are seen through both.  A view satisfies
+// Table, so can be wrapped by any renderer.  Methods which a view does not
+// change pass through to the table beneath, including those which add rows
+// or headers, set a schema or the totals label, and set properties and
+// callbacks; each view type says which others it changes.
+//
+// Rows are edited by position through a view using the view's positions, as
+// for NRows and CellAt.  A projection shows every row of the table beneath,
+// so its positions are those of that table, and the rows it is given to
+// insert or replace have the columns of that table.
+
+// A FilterView is a Table showing only those rows of another table for which
+// a predicate is true.  The predicate is consulted afresh each time the rows
+// are asked for, so is applied to rows added after the view was made.
+//
+// Separators are kept where they would still separate rows, and not at the
+// start or end of the body, nor next to each other.  Positions within the
+// view, as for NRows and CellAt, count only the rows shown; a Row's own
+// Location is still its position in the table beneath.  The totals row, and
+// cells spanning rows, are computed from every row of the table beneath;
+// use OmitUnless if they should follow the predicate.
+//
+// The columns of a filter are those of the table beneath, so methods taking
+// column numbers, which edit columns, sort or group, pass through unchanged
+// and act upon the table beneath: sorting or grouping through a filter orders
+// every row of that table, shown or not.
+type FilterView struct {
+	Table
+	keep func(*Row) bool
+}
+
+var _ Table = (*FilterView)(nil)
+
+// Filter returns a view of the table showing only those rows for which keep
+// returns true.  The predicate is given the rows with cells, not separators.
+func (t *ATable) Filter(keep func(*Row) bool) Table {
+	return &FilterView{Table: t, keep: keep}
+}
+
+// Filter returns a view of the view, showing only those rows of it for which
+// keep returns true.
+func (fv *FilterView) Filter(keep func(*Row) bool) Table {
+	return &FilterView{Table: fv, keep: keep}
+}
+
+// AllRows returns the rows shown by the view.
+func (fv *FilterView) AllRows() []*Row {
+	rows, _ := fv.shown()
+	return rows
+}
+
+// shown returns the rows shown by the view, and the position of each in the
+// table beneath.
+func (fv *FilterView) shown() (rows []*Row, positions []int) {
+	all := fv.Table.AllRows()
+	rows = make([]*Row, 0, len(all))
+	positions = make([]int, 0, len(all))
+	separator := 0
+	for i, row := range all {
+		if row.IsSeparator() {
+			if len(rows) > 0 {
+				separator = i + 1
+			}
+			continue
+		}
+		if !fv.keep(row) {
+			continue
+		}
+		if separator != 0 {
+			rows = append(rows, all[separator-1])
+			positions = append(positions, separator)
+			separator = 0
+		}
+		rows = append(rows, row)
+		positions = append(positions, i+1)
+	}
+	return rows, positions
+}
+
+// position returns the position in the table beneath of the row shown at a
+// position of the view.
+func (fv *FilterView) position(position int) (int, error) {
+	_, positions := fv.shown()
+	if position < 1 || position > len(positions) {
+		return 0, ErrorRowOutOfRange(position)
+	}
+	return positions[position-1], nil
+}
+
+// InsertRowAt adds a row to the table beneath so that it is shown at the
+// given position of the view, if the predicate keeps it, just above the row
+// shown there before; a position of NRows()+1 puts it after the last row
+// shown.
+func (fv *FilterView) InsertRowAt(position int, row *Row) error {
+	_, positions := fv.shown()
+	switch {
+	case position < 1 || position > len(positions)+1:
+		return ErrorRowOutOfRange(position)
+	case position <= len(positions):
+		return fv.Table.InsertRowAt(positions[position-1], row)
+	case len(positions) > 0:
+		return fv.Table.InsertRowAt(positions[len(positions)-1]+1, row)
+	}
+	return fv.Table.InsertRowAt(fv.Table.NRows()+1, row)
+}
+
+// RemoveRow takes the row shown at the given position of the view out of
+// the table beneath, and returns it.
+func (fv *FilterView) RemoveRow(position int) (*Row, error) {
+	n, err := fv.position(position)
+	if err != nil {
+		return nil, err
+	}
+	return fv.Table.RemoveRow(n)
+}
+
+// MoveRow moves the row shown at one position of the view within the table
+// beneath, so that it is shown at another.
+func (fv *FilterView) MoveRow(from, to int) error {
+	m, err := fv.position(from)
+	if err != nil {
+		return err
+	}
+	n, err := fv.position(to)
+	if err != nil {
+		return err
+	}
+	return fv.Table.MoveRow(m, n)
+}
+
+// ReplaceRow puts a row into the table beneath in place of the row shown at
+// the given position of the view, returning the row replaced.
+func (fv *FilterView) ReplaceRow(position int, row *Row) (*Row, error) {
+	n, err := fv.position(position)
+	if err != nil {
+		return nil, err
+	}
+	return fv.Table.ReplaceRow(n, row)
+}
+
+// Truncate removes from the table beneath every row shown by the view after
+// the first count; rows which the view does not show are kept.
+func (fv *FilterView) Truncate(count int) error {
+	_, positions := fv.shown()
+	if count < 0 || count > len(positions) {
+		return ErrorRowOutOfRange(count)
+	}
+	for i := len(positions) - 1; i >= count; i-- {
+		if _, err := fv.Table.RemoveRow(positions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NRows returns the count of rows shown by the view, including separators.
+func (fv *FilterView) NRows() int {
+	return len(fv.AllRows())
+}
+
+// CellAt returns the cell at a location within the view.
+func (fv *FilterView) CellAt(loc CellLocation) (*Cell, error) {
+	rows := fv.AllRows()
+	if loc.Row < 1 || loc.Column < 1 || loc.Row > len(rows) {
+		return nil, NoSuchCellError{Location: loc}
+	}
+	r := rows[loc.Row-1]
+	if r.cells == nil || loc.Column > len(r.cells) {
+		return nil, NoSuchCellError{Location: loc}
+	}
+	return r.cells[loc.Column-1].SpanAnchor(), nil
+}
+
+// SetCellAt replaces the item in the cell at a location within the view.
+func (fv *FilterView) SetCellAt(loc CellLocation, item any) error {
+	c, err := fv.CellAt(loc)
+	if err != nil {
+		return err
+	}
+	c.SetItem(item)
+	return nil
+}
+
+// RegisterPropertyCallback passes through to the table beneath, with
+// callbacks registered upon the view being registered upon that table.
+func (fv *FilterView) RegisterPropertyCallback(
+	owner PropertyOwner,
+	when callbackTime,
+	target cbTarget,
+	theNewCallback PropertyCallback,
+) error {
+	if owner == PropertyOwner(fv) {
+		owner = fv.Table
+	}
+	return fv.Table.RegisterPropertyCallback(owner, when, target, theNewCallback)
+}
+
+// OmitUnless marks every row of the body for which keep returns false with
+// the properties.Omit property, so that renderers skip it, and clears the mark
+// from every row for which keep returns true; separators are left alone.
+// Unlike a view from Filter, this changes the table, and the totals row then
+// aggregates only the rows which are shown.  The table is returned, to
+// permit chaining.
+func (t *ATable) OmitUnless(keep func(*Row) bool) Table {
+	for _, row := range t.rows {
+		if row.isSeparator {
+			continue
+		}
+		row.SetProperty(properties.Omit, !keep(row))
+	}
+	return t
+}
+
+// A ProjectionView is a Table showing only some of the columns of another
+// table, in a chosen order.  The columns are the Columns of the table
+// beneath, so their properties, including properties.Omit, are shared; the
+// table beneath is not changed by the projection.
+//
+// The rows of a projection are made afresh each time they are asked for,
+// holding copies of the cells of the rows beneath, so that cells can be
+// changed through CellAt but not through the rows.  A cell spanning columns
+// spans only those of its columns which stay next to each other, in order,
+// and is shown in the first of them; cells spanning rows are shown only in
+// their first row.
+//
+// Sorting and grouping through a projection take its own column numbers, and
+// names only of the columns it shows, and order every row of the table
+// beneath.  A column shown can be renamed through a projection, but columns
+// cannot be inserted, deleted, moved or computed through one, so those
+// methods fail with ErrProjectionColumns.
+type ProjectionView struct {
+	Table
+	columns []int
+}
+
+var _ Table = (*ProjectionView)(nil)
+
+// ErrProjectionColumns is returned for an attempt to change which columns
+// there are through a projection.
+var ErrProjectionColumns = errors.New("can't insert, delete, move or compute columns through a projection")
+
+// Project returns a view of the table showing only the columns with the
+// given names, as found by ColumnNamed, in the order given.
+func (t *ATable) Project(names ...string) (Table, error) {
+	return newProjectionView(t, names)
+}
+
+// Project returns a view of the view, showing only the named columns.
+func (fv *FilterView) Project(names ...string) (Table, error) {
+	return newProjectionView(fv, names)
+}
+
+// Project returns a view of the view, showing only the named columns of it.
+func (pv *ProjectionView) Project(names ...string) (Table, error) {
+	return newProjectionView(pv, names)
+}
+
+func newProjectionView(t Table, names []string) (*ProjectionView, error) {
+	pv := &ProjectionView{Table: t, columns: make([]int, len(names))}
+	for i, name := range names {
+		n, err := columnNumberNamed(t, name)
+		if err != nil {
+			return nil, err
+		}
+		pv.columns[i] = n
+	}
+	return pv, nil
+}
+
+// columnNumberNamed returns the number, counting from 1, of the named column
+// of any Table.
+func columnNumberNamed(t Table, name string) (int, error) {
+	column, err := t.ColumnNamed(name)
+	if err != nil {
+		return 0, err
+	}
+	for n := 1; n <= t.NColumns(); n++ {
+		if t.Column(n) == column {
+			return n, nil
+		}
+	}
+	return 0, ErrorNoSuchColumn(name)
+}
+
+// Filter returns a view of the projection, showing only those rows for
+// which keep returns true; keep is given the rows of the projection.
+func (pv *ProjectionView) Filter(keep func(*Row) bool) Table {
+	return &FilterView{Table: pv, keep: keep}
+}
+
+// NColumns returns the count of columns shown by the projection.
+func (pv *ProjectionView) NColumns() int {
+	return len(pv.columns)
+}
+
+// Column returns the Column of the table beneath shown as the given column
+// of the projection, or the defaults Column for 0.
+func (pv *ProjectionView) Column(n int) *Column {
+	if n == 0 {
+		return pv.Table.Column(0)
+	}
+	if n < 0 || n > len(pv.columns) {
+		return nil
+	}
+	return pv.Table.Column(pv.columns[n-1])
+}
+
+// ColumnNamed returns the named Column, if it is shown by the projection.
+func (pv *ProjectionView) ColumnNamed(name string) (*Column, error) {
+	column, err := pv.Table.ColumnNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range pv.columns {
+		if pv.Table.Column(n) == column {
+			return column, nil
+		}
+	}
+	return nil, ErrorNoSuchColumn(name)
+}
+
+// Headers returns the headers of the columns shown.
+func (pv *ProjectionView) Headers() []Cell {
+	headers := pv.Table.Headers()
+	if len(headers) == 0 {
+		return headers
+	}
+	return projectRow(headers[0].Row(), pv.columns).Cells()
+}
+
+// HeaderGroupRows returns the header group rows, projected.
+func (pv *ProjectionView) HeaderGroupRows() []*Row {
+	return projectRows(pv.Table.HeaderGroupRows(), pv.columns)
+}
+
+// AllRows returns the rows of the body, projected.  Row.CellNamed and
+// Row.AsMap upon these rows find the columns of the projection.
+func (pv *ProjectionView) AllRows() []*Row {
+	rows := projectRows(pv.Table.AllRows(), pv.columns)
+	headers := pv.Headers()
+	if len(headers) == 0 {
+		return rows
+	}
+	columnNames := make(map[string]int, len(headers))
+	for i := range headers {
+		if !headers[i].SpanCovered() {
+			columnNames[headers[i].String()] = i
+		}
+	}
+	for _, row := range rows {
+		if row.cells != nil {
+			row.columnNames = columnNames
+		}
+	}
+	return rows
+}
+
+// FooterRows returns the footer rows, including any totals row, projected.
+func (pv *ProjectionView) FooterRows() []*Row {
+	return projectRows(pv.Table.FooterRows(), pv.columns)
+}
+
+// TotalsRow returns the totals row, projected, or nil.
+func (pv *ProjectionView) TotalsRow() *Row {
+	return projectRow(pv.Table.TotalsRow(), pv.columns)
+}
+
+// CellAt returns the cell of the table beneath at a location within the
+// projection.
+func (pv *ProjectionView) CellAt(loc CellLocation) (*Cell, error) {
+	if loc.Column < 1 || loc.Column > len(pv.columns) {
+		return nil, NoSuchCellError{Location: loc}
+	}
+	cell, err := pv.Table.CellAt(CellLocation{Row: loc.Row, Column: pv.columns[loc.Column-1]})
+	if err != nil {
+		return nil, NoSuchCellError{Location: loc}
+	}
+	return cell, nil
+}
+
+// SetCellAt replaces the item in the cell of the table beneath at a location
+// within the projection.
+func (pv *ProjectionView) SetCellAt(loc CellLocation, item any) error {
+	c, err := pv.CellAt(loc)
+	if err != nil {
+		return err
+	}
+	c.SetItem(item)
+	return nil
+}
+
+// RenameColumn renames a column shown by the projection, in the table
+// beneath.
+func (pv *ProjectionView) RenameColumn(oldName, newName string) error {
+	if _, err := pv.ColumnNamed(oldName); err != nil {
+		return err
+	}
+	return pv.Table.RenameColumn(oldName, newName)
+}
+
+// InsertColumn fails with ErrProjectionColumns.
+func (pv *ProjectionView) InsertColumn(column int, header any, items ...any) error {
+	return ErrProjectionColumns
+}
+
+// DeleteColumn fails with ErrProjectionColumns.
+func (pv *ProjectionView) DeleteColumn(column int) error {
+	return ErrProjectionColumns
+}
+
+// MoveColumn fails with ErrProjectionColumns.
+func (pv *ProjectionView) MoveColumn(from, to int) error {
+	return ErrProjectionColumns
+}
+
+// AddComputedColumn records ErrProjectionColumns as an error of the table
+// beneath, which is otherwise left alone.  The projection is returned.
+func (pv *ProjectionView) AddComputedColumn(name string, fn func(*Row) any) Table {
+	pv.AddError(ErrProjectionColumns)
+	return pv
+}
+
+// SortByNamedColumn sorts the table beneath by a column shown by the
+// projection.
+func (pv *ProjectionView) SortByNamedColumn(name string, order SortOrder) error {
+	return pv.SortBy(SortKey{Name: name, Order: order})
+}
+
+// SortByColumnNumber sorts the table beneath by a column of the projection,
+// counting from 0.
+func (pv *ProjectionView) SortByColumnNumber(sortCol int, order SortOrder) error {
+	if sortCol < 0 || sortCol >= len(pv.columns) {
+		return ErrorColumnOutOfRange(sortCol)
+	}
+	return pv.SortBy(SortKey{Column: sortCol + 1, Order: order})
+}
+
+// SortBy sorts the table beneath, as its SortBy does, on keys naming or
+// numbering the columns of the projection.
+func (pv *ProjectionView) SortBy(keys ...SortKey) error {
+	keys, err := pv.underlyingKeys(keys)
+	if err != nil {
+		return err
+	}
+	return pv.Table.SortBy(keys...)
+}
+
+// SortGroupsBy sorts the table beneath, as its SortGroupsBy does, on keys
+// naming or numbering the columns of the projection.
+func (pv *ProjectionView) SortGroupsBy(keys ...SortKey) error {
+	keys, err := pv.underlyingKeys(keys)
+	if err != nil {
+		return err
+	}
+	return pv.Table.SortGroupsBy(keys...)
+}
+
+// GroupBy groups the table beneath, as its GroupBy does, on a key and
+// totals naming or numbering the columns of the projection.
+func (pv *ProjectionView) GroupBy(key SortKey, totals ...GroupTotal) error {
+	keys, err := pv.underlyingKeys([]SortKey{key})
+	if err != nil {
+		return err
+	}
+	underlying := make([]GroupTotal, len(totals))
+	for i := range totals {
+		k, err := pv.underlyingKey(SortKey{Column: totals[i].Column, Name: totals[i].Name})
+		if err != nil {
+			return err
+		}
+		underlying[i] = GroupTotal{Column: k.Column, Name: k.Name, Aggregator: totals[i].Aggregator}
+	}
+	return pv.Table.GroupBy(keys[0], underlying...)
+}
+
+// underlyingKeys returns copies of sort keys for the table beneath, each
+// naming a column shown by the projection or numbering it as the table
+// beneath does.
+func (pv *ProjectionView) underlyingKeys(keys []SortKey) ([]SortKey, error) {
+	underlying := make([]SortKey, len(keys))
+	for i := range keys {
+		k, err := pv.underlyingKey(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		underlying[i] = k
+	}
+	return underlying, nil
+}
+
+func (pv *ProjectionView) underlyingKey(key SortKey) (SortKey, error) {
+	if key.Name != "" {
+		_, err := pv.ColumnNamed(key.Name)
+		return key, err
+	}
+	if key.Column < 1 || key.Column > len(pv.columns) {
+		return key, ErrorColumnOutOfRange(key.Column)
+	}
+	key.Column = pv.columns[key.Column-1]
+	return key, nil
+}
+
+// RegisterPropertyCallback passes through to the table beneath, with
+// callbacks registered upon the view being registered upon that table.
+func (pv *ProjectionView) RegisterPropertyCallback(
+	owner PropertyOwner,
+	when callbackTime,
+	target cbTarget,
+	theNewCallback PropertyCallback,
+) error {
+	if owner == PropertyOwner(pv) {
+		owner = pv.Table
+	}
+	return pv.Table.RegisterPropertyCallback(owner, when, target, theNewCallback)
+}
+
+func projectRows(rows []*Row, columns []int) []*Row {
+	if rows == nil {
+		return nil
+	}
+	projected := make([]*Row, len(rows))
+	for i := range rows {
+		projected[i] = projectRow(rows[i], columns)
+	}
+	return projected
+}
+
+// projectRow returns a copy of the row holding copies of the cells in the
+// given columns, counting from 1; separators are returned as they are.
+func projectRow(row *Row, columns []int) *Row {
+	if row == nil || row.cells == nil {
+		return row
+	}
+	projected := *row
+	projected.cells = make([]Cell, len(columns))
+	for j := 0; j < len(columns); j++ {
+		i := columns[j] - 1
+		if i >= len(row.cells) || (row.cells[i].spanFrom.row != nil && row.cells[i].spanFrom.row != row) {
+			// beyond a short row, or covered by a cell from a row above
+			projected.cells[j] = NewCell(nil)
+			continue
+		}
+		start, count := row.columnRegion(i)
+		run := 1
+		for j+run < len(columns) && columns[j+run] == columns[j]+run && columns[j+run]-1 < start+count {
+			run++
+		}
+		cell := *row.cells[i].SpanAnchor()
+		cell.span = cellSpan{columns: run, rows: 1}
+		cell.spanFrom = spanOrigin{}
+		projected.cells[j] = cell
+		for k := 1; k < run; k++ {
+			projected.cells[j+k] = NewCell(nil)
+		}
+		j += run - 1
+	}
+	projected.renumberCells()
+	markColumnSpans(&projected)
+	return &projected
+}
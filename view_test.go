@@ -0,0 +1,267 @@
+// Copyright © 2026 Pennock Tech, LLC.
+// All rights reserved, except as granted under license.
+// Licensed per file LICENSE.txt
+
+package tabular_test // import "go.pennock.tech/tabular"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/liquidgecka/testlib"
+
+	"go.pennock.tech/tabular"
+	"go.pennock.tech/tabular/csv"
+	"go.pennock.tech/tabular/properties"
+	"go.pennock.tech/tabular/texttable"
+)
+
+func stateIs(state string) func(*tabular.Row) bool {
+	return func(r *tabular.Row) bool { return r.Cells()[1].String() == state }
+}
+
+func populateJobs(tb tabular.Table) {
+	tb.AddHeaders("Job", "State", "Minutes")
+	tb.AddRowItems("build", "done", 4)
+	tb.AddRowItems("test", "running", 12)
+	tb.AddSeparator()
+	tb.AddRowItems("lint", "done", 1)
+	tb.AddSeparator()
+	tb.AddRowItems("deploy", "queued", 0)
+	tb.AddRowItems("notify", "running", 2)
+}
+
+func TestFilterView(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	populateJobs(tb)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+
+	running := tb.Filter(stateIs("running"))
+	T.Equal(firstColumn(running), []string{"test", "--", "notify"}, "filtered, with a separator between groups")
+	T.Equal(running.NRows(), 3, "view counts only rows shown")
+	c, err := running.CellAt(tabular.CellLocation{Row: 3, Column: 1})
+	T.ExpectSuccess(err, "cell within view")
+	T.Equal(c.String(), "notify", "cell located within view")
+	_, err = running.CellAt(tabular.CellLocation{Row: 4, Column: 1})
+	T.ExpectError(err, "no cell beyond view")
+
+	done := tb.Filter(stateIs("done"))
+	T.Equal(firstColumn(done), []string{"build", "--", "lint"}, "separators kept between groups")
+	T.Equal(firstColumn(tb.Filter(stateIs("queued"))), []string{"deploy"}, "no separators at the edges")
+	T.Equal(firstColumn(done.Filter(func(r *tabular.Row) bool { return r.Cells()[0].String() != "build" })), []string{"lint"}, "views compose")
+
+	tb.AddRowItems("rebuild", "done", 3)
+	T.Equal(firstColumn(done), []string{"build", "--", "lint", "--", "rebuild"}, "view sees rows added later")
+	T.Equal(tb.NRows(), 8, "table itself unchanged")
+
+	text := texttable.Wrap(running)
+	_, err = text.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	rendered, err := text.Render()
+	T.ExpectSuccess(err, "view rendered as text")
+	T.Equal(rendered, strings.TrimLeft(`
++--------+---------+---------+
+| Job    | State   | Minutes |
++--------+---------+---------+
+| test   | running | 12      |
++--------+---------+---------+
+| notify | running | 2       |
++--------+---------+---------+
+| Total  |         | 22      |
++--------+---------+---------+
+`, "\n"), "view rendered as text, totals from every row")
+
+	rendered, err = csv.Wrap(done).Render()
+	T.ExpectSuccess(err, "view rendered as CSV")
+	T.Equal(rendered, `"Job","State","Minutes"
+"build","done","4"
+"lint","done","1"
+"rebuild","done","3"
+"Total","","22"
+`, "view rendered as CSV")
+	T.Equal(tb.Errors(), nil, "no errors through views")
+}
+
+func TestViewRowEdits(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	populateJobs(tb)
+	running := tb.Filter(stateIs("running"))
+
+	removed, err := running.RemoveRow(running.NRows())
+	T.ExpectSuccess(err, "remove last row of view")
+	T.Equal(removed.Cells()[0].String(), "notify", "row removed by view position")
+	T.Equal(firstColumn(tb), []string{"build", "test", "--", "lint", "--", "deploy"}, "only that row removed")
+	_, err = running.RemoveRow(2)
+	T.Equal(err, tabular.ErrorRowOutOfRange(2), "no row beyond view")
+
+	T.ExpectSuccess(running.InsertRowAt(1, tabular.NewRow().Add(tabular.NewCell("fetch")).Add(tabular.NewCell("running"))), "insert at top of view")
+	T.ExpectSuccess(running.InsertRowAt(running.NRows()+1, tabular.NewRow().Add(tabular.NewCell("bench")).Add(tabular.NewCell("running"))), "append to view")
+	T.Equal(firstColumn(running), []string{"fetch", "test", "bench"}, "rows placed by view position")
+	T.Equal(firstColumn(tb), []string{"build", "fetch", "test", "bench", "--", "lint", "--", "deploy"}, "rows placed next to those shown")
+
+	T.ExpectSuccess(running.MoveRow(3, 1), "move within view")
+	T.Equal(firstColumn(running), []string{"bench", "fetch", "test"}, "moved by view positions")
+	old, err := running.ReplaceRow(2, tabular.NewRow().Add(tabular.NewCell("pull")).Add(tabular.NewCell("running")))
+	T.ExpectSuccess(err, "replace within view")
+	T.Equal(old.Cells()[0].String(), "fetch", "replaced row shown at that position")
+	T.ExpectSuccess(running.Truncate(1), "truncate view")
+	T.Equal(firstColumn(tb), []string{"build", "bench", "--", "lint", "--", "deploy"}, "only rows shown truncated")
+
+	pv, err := tb.Project("Job")
+	T.ExpectSuccess(err, "project")
+	removed, err = pv.RemoveRow(pv.NRows())
+	T.ExpectSuccess(err, "remove last row of projection")
+	T.Equal(removed.Cells()[0].String(), "deploy", "projection positions are the table's")
+}
+
+func TestOmitUnless(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := csv.New()
+	populateJobs(tb)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+
+	tb.OmitUnless(stateIs("running"))
+	T.Equal(tb.NRows(), 7, "rows marked, not removed")
+	T.Equal(tb.AllRows()[0].GetProperty(properties.Omit), true, "non-matching row marked")
+	T.Equal(tb.AllRows()[1].GetProperty(properties.Omit), false, "matching row not marked")
+	rendered, err := tb.Render()
+	T.ExpectSuccess(err, "marked table rendered")
+	T.Equal(rendered, `"Job","State","Minutes"
+"test","running","12"
+"notify","running","2"
+"Total","","14"
+`, "omitted rows skipped, and not totalled")
+
+	tb.OmitUnless(stateIs("queued"))
+	rendered, err = tb.Render()
+	T.ExpectSuccess(err, "remarked table rendered")
+	T.Equal(rendered, `"Job","State","Minutes"
+"deploy","queued","0"
+"Total","","0"
+`, "marks replaced")
+	T.Equal(tb.Errors(), nil, "no errors omitting rows")
+}
+
+func TestProjectionView(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaderGroups(tabular.HeaderGroup{Name: "Task", Columns: 2}, tabular.HeaderGroup{Name: "Time", Columns: 1})
+	populateJobs(tb)
+	tb.Column(3).SetAggregator(tabular.AGG_SUM)
+	tb.SetTotalsLabel("Total")
+	tb.Column(2).SetProperty("marker", "state")
+
+	_, err := tb.Project("Job", "Owner")
+	T.Equal(err, tabular.ErrorNoSuchColumn("Owner"), "unknown column rejected")
+
+	pv, err := tb.Project("State", "Job")
+	T.ExpectSuccess(err, "project two columns")
+	T.Equal(pv.NColumns(), 2, "projection has the columns chosen")
+	T.Equal(headerNames(pv), []string{"State", "Job"}, "headers in the order chosen")
+	T.Equal(pv.Column(1).GetProperty("marker"), "state", "columns shared with the table beneath")
+	_, err = pv.ColumnNamed("Minutes")
+	T.Equal(err, tabular.ErrorNoSuchColumn("Minutes"), "columns not projected are not found")
+	c, err := pv.CellAt(tabular.CellLocation{Row: 2, Column: 2})
+	T.ExpectSuccess(err, "cell within projection")
+	T.Equal(c.String(), "test", "cell located within projection")
+	T.Equal(tb.NColumns(), 3, "table beneath unchanged")
+
+	row := pv.AllRows()[0]
+	c, err = row.CellNamed("Job")
+	T.ExpectSuccess(err, "cell of projected row by name")
+	T.Equal(c.String(), "build", "name found through the projection")
+	_, err = row.CellNamed("Minutes")
+	T.Equal(err, tabular.ErrorNoSuchColumn("Minutes"), "columns not projected are not found by name")
+	T.Equal(row.AsMap(), map[string]any{"State": "done", "Job": "build"}, "projected row as a map")
+
+	running, err := tb.Filter(stateIs("running")).Project("Minutes", "Job")
+	T.ExpectSuccess(err, "project a filter")
+	text := texttable.Wrap(running)
+	_, err = text.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	rendered, err := text.Render()
+	T.ExpectSuccess(err, "projection rendered as text")
+	T.Equal(rendered, strings.TrimLeft(`
++---------+--------+
+| Time    | Task   |
++---------+--------+
+| Minutes | Job    |
++---------+--------+
+| 12      | test   |
++---------+--------+
+| 2       | notify |
++---------+--------+
+| 19      | Total  |
++---------+--------+
+`, "\n"), "filtered projection rendered as text")
+
+	grouped, err := tb.Project("Job", "State")
+	T.ExpectSuccess(err, "project a header group")
+	gv := grouped.Filter(stateIs("done"))
+	text = texttable.Wrap(gv)
+	_, err = text.SetDecorationNamed("ascii-simple")
+	T.ExpectSuccess(err, "set decoration")
+	rendered, err = text.Render()
+	T.ExpectSuccess(err, "projection with group rendered as text")
+	T.Equal(rendered, strings.TrimLeft(`
++---------------+
+| Task          |
++-------+-------+
+| Job   | State |
++-------+-------+
+| build | done  |
++-------+-------+
+| lint  | done  |
++-------+-------+
+| Total |       |
++-------+-------+
+`, "\n"), "header group spans the columns kept together")
+	T.Equal(tb.Errors(), nil, "no errors through projections")
+}
+
+func TestProjectionViewEdits(t *testing.T) {
+	T := testlib.NewT(t)
+	defer T.Finish()
+
+	tb := tabular.New()
+	tb.AddHeaders("Job", "State", "Minutes")
+	tb.AddRowItems("a", "z", 3)
+	tb.AddRowItems("b", "y", 1)
+	tb.AddRowItems("c", "x", 2)
+	pv, err := tb.Project("Minutes", "Job")
+	T.ExpectSuccess(err, "project two columns")
+
+	T.ExpectSuccess(pv.SortBy(tabular.SortKey{Column: 1}), "sort by a column of the projection")
+	T.Equal(firstColumn(tb), []string{"b", "c", "a"}, "sorted by the projection's first column")
+	T.ExpectSuccess(pv.SortByColumnNumber(1, tabular.SORT_DESC), "legacy sort by a column of the projection")
+	T.Equal(firstColumn(tb), []string{"c", "b", "a"}, "sorted by the projection's second column")
+	T.ExpectSuccess(pv.SortByNamedColumn("Minutes", tabular.SORT_ASC), "sort by a named column of the projection")
+	T.Equal(firstColumn(tb), []string{"b", "c", "a"}, "sorted by name")
+	T.Equal(pv.SortBy(tabular.SortKey{Name: "State"}), tabular.ErrorNoSuchColumn("State"), "column not shown can't be sorted upon")
+	T.Equal(pv.SortBy(tabular.SortKey{Column: 3}), tabular.ErrorColumnOutOfRange(3), "column beyond the projection")
+	T.ExpectSuccess(pv.GroupBy(tabular.SortKey{Column: 2, Order: tabular.SORT_DESC}), "group by a column of the projection")
+	T.Equal(firstColumn(tb), []string{"c", "--", "b", "--", "a"}, "grouped by the projection's second column")
+
+	T.Equal(pv.InsertColumn(1, "Owner"), tabular.ErrProjectionColumns, "no column inserted through a projection")
+	T.Equal(pv.DeleteColumn(1), tabular.ErrProjectionColumns, "no column deleted through a projection")
+	T.Equal(pv.MoveColumn(1, 2), tabular.ErrProjectionColumns, "no column moved through a projection")
+	T.Equal(tb.NColumns(), 3, "table beneath keeps its columns")
+	T.ExpectSuccess(pv.RenameColumn("Job", "Task"), "rename a column shown")
+	T.Equal(headerNames(pv), []string{"Minutes", "Task"}, "renamed through the projection")
+	T.Equal(pv.RenameColumn("State", "Status"), tabular.ErrorNoSuchColumn("State"), "column not shown can't be renamed")
+	T.Equal(tb.Errors(), nil, "no errors recorded")
+	pv.AddComputedColumn("Hours", func(*tabular.Row) any { return nil })
+	T.Equal(tb.Errors(), []error{tabular.ErrProjectionColumns}, "no column computed through a projection")
+}